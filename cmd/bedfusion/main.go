@@ -1,6 +1,9 @@
 package main
 
 import (
+	"fmt"
+	"reflect"
+
 	"github.com/alecthomas/kong"
 	kongyaml "github.com/alecthomas/kong-yaml"
 
@@ -28,7 +31,9 @@ func main() {
 		kong.Description("Another tool for sorting and merging bed files.\n\n"+
 			"BedFusion follows the bed file standard outlined in: https://github.com/samtools/hts-specs/blob/94500cf76f049e898dec7af23097d877fde5894e/BEDv1.pdf \n\n"+
 			"Read priority order: 1. flags 2. configuration file 3. environmental variables \n\n"+
-			"Order of actions: 1. reading files 2. padding(*) 3. merging(*)/deduplication(*) 4. sorting 5. writing output (* = can be turned on/off using flags)"),
+			"Order of actions: 1. reading files 2. lifting over(*) 3. validating(*) 4. padding(*)/flanking(*) 5. merging(*)/deduplication(*) 6. making windows(*) 7. intersecting(*) 8. subtracting(*) 9. complementing(*) 10. finding closest regions(*) 11. searching windows(*) 12. sorting 13. writing output/scattering(*) (* = can be turned on/off using flags). "+
+			"When streaming (--stream) reading, validating, padding, merging and writing is done line by line, and sorting is replaced by a check of the input order. "+
+			"As the order is checked before padding, padding that can change the order of the regions (fractional --padding, --padding-col, --pad-upstream, --pad-downstream, --min-size, --shrink and --shift-strand) can not be used when streaming"),
 		kong.Vars{
 			// Sorting types
 			"lexST":  bed.LexST,
@@ -39,18 +44,97 @@ func main() {
 			"failPT":  bed.SafePT,
 			"warnPT":  bed.LaxPT,
 			"forcePT": bed.ForcePT,
+			// Flank types
+			"noneFT":       bed.NoneFT,
+			"bothFT":       bed.BothFT,
+			"leftFT":       bed.LeftFT,
+			"rightFT":      bed.RightFT,
+			"upstreamFT":   bed.UpstreamFT,
+			"downstreamFT": bed.DownstreamFT,
+			// Validation types
+			"noneVT": bed.NoneVT,
+			"failVT": bed.FailVT,
+			"warnVT": bed.WarnVT,
+			"dropVT": bed.DropVT,
+			// Chromosome prefix types
+			"keepCP":  bed.KeepCP,
+			"addCP":   bed.AddCP,
+			"stripCP": bed.StripCP,
+			// Mitochondrial chromosome types
+			"keepCM": bed.KeepCM,
+			"mCM":    bed.MCM,
+			"mtCM":   bed.MTCM,
+			// Make windows types
+			"noneMW":    bed.NoneMW,
+			"regionsMW": bed.RegionsMW,
+			"genomeMW":  bed.GenomeMW,
+			// Index types
+			"noneIT": bed.NoneIT,
+			"tbiIT":  bed.TbiIT,
+			"csiIT":  bed.CsiIT,
+			// Intersect reports
+			"overlapIR":  bed.OverlapIR,
+			"overlapBIR": bed.OverlapBIR,
+			"aIR":        bed.AIR,
+			"abIR":       bed.ABIR,
+			"uniqueIR":   bed.UniqueIR,
+			// Closest ties
+			"allCT":   bed.AllCT,
+			"firstCT": bed.FirstCT,
+			"lastCT":  bed.LastCT,
+			// Closest directions
+			"bothCD":       bed.BothCD,
+			"upstreamCD":   bed.UpstreamCD,
+			"downstreamCD": bed.DownstreamCD,
 		},
+		kong.NamedMapper("padding", kong.MapperFunc(paddingMapper)),
 		kong.Configuration(kongyaml.Loader),
 		kong.UsageOnError(),
 	)
 	s.ctx.FatalIfErrorf(s.run())
 }
 
+// Read padding as text, as it can be given both as a
+// number and as a fraction (e.g. 0.1x) in the configuration file
+func paddingMapper(ctx *kong.DecodeContext, target reflect.Value) error {
+	token, err := ctx.Scan.PopValue("padding")
+	if err != nil {
+		return err
+	}
+	target.SetString(fmt.Sprint(token.Value))
+	return nil
+}
+
 func (s *session) run() (error, string) {
+	// Merge, pad and write while reading
+	if s.Bedfile.Stream {
+		if err := s.Bedfile.StreamLines(); err != nil {
+			return err, "while streaming"
+		}
+		return nil, ""
+	}
 	// Read bed file
 	if err := s.Bedfile.Read(); err != nil {
 		return err, "while reading"
 	}
+	// Lift over
+	if s.Bedfile.Liftover != "" {
+		if err := s.Bedfile.LiftoverLines(); err != nil {
+			return err, "while lifting over"
+		}
+	}
+	// Validate
+	if s.Bedfile.ValidateSelected() {
+		if err := s.Bedfile.ValidateLines(); err != nil {
+			return err, "while validating"
+		}
+	}
+	// Flank lines
+	if s.Bedfile.FlankSelected() {
+		if err := s.Bedfile.FlankLines(); err != nil {
+			return err, "while flanking"
+		}
+	}
 	if !s.Bedfile.NoMerge {
 		// Merge and pad lines
 		if err := s.Bedfile.MergeAndPadLines(); err != nil {
@@ -58,7 +142,7 @@ func (s *session) run() (error, string) {
 		}
 	} else {
 		// Pad lines
-		if s.Bedfile.Padding != 0 {
+		if s.Bedfile.PaddingSelected() {
 			if err := s.Bedfile.PadLines(); err != nil {
 				return err, "while padding"
 			}
@@ -68,10 +152,53 @@ func (s *session) run() (error, string) {
 			s.Bedfile.DeduplicateLines()
 		}
 	}
+	// Make windows
+	if s.Bedfile.MakeWindowsSelected() {
+		if err := s.Bedfile.MakeWindowsLines(); err != nil {
+			return err, "while making windows"
+		}
+	}
+	// Intersect
+	if s.Bedfile.Intersect != "" {
+		if err := s.Bedfile.IntersectLines(); err != nil {
+			return err, "while intersecting"
+		}
+	}
+	// Subtract
+	if s.Bedfile.Subtract != "" {
+		if err := s.Bedfile.SubtractLines(); err != nil {
+			return err, "while subtracting"
+		}
+	}
+	// Complement
+	if s.Bedfile.Complement {
+		if err := s.Bedfile.ComplementLines(); err != nil {
+			return err, "while complementing"
+		}
+	}
+	// Closest
+	if s.Bedfile.Closest != "" {
+		if err := s.Bedfile.ClosestLines(); err != nil {
+			return err, "while finding closest regions"
+		}
+	}
+	// Window
+	if s.Bedfile.Window != "" {
+		if err := s.Bedfile.WindowLines(); err != nil {
+			return err, "while searching windows"
+		}
+	}
 	// Sort
 	if err := s.Bedfile.Sort(); err != nil {
 		return err, "while sorting"
 	}
+	// Scatter output
+	if s.Bedfile.Scatter != 0 {
+		if err := s.Bedfile.WriteScattered(); err != nil {
+			return err, "while scattering"
+		}
+		return nil, ""
+	}
 	// Write output
 	if err := s.Bedfile.Write(); err != nil {
 		return err, "while writing"
@@ -0,0 +1,109 @@
+package bed
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Column operations used when merging
+var SumCO = "sum"                      // Sum of the values
+var MeanCO = "mean"                    // Mean of the values
+var MedianCO = "median"                // Median of the values
+var MinCO = "min"                      // Smallest value
+var MaxCO = "max"                      // Largest value
+var CountCO = "count"                  // Number of values
+var CountDistinctCO = "count_distinct" // Number of distinct values
+var DistinctCO = "distinct"            // Comma separated list of the distinct values
+var CollapseCO = "collapse"            // Comma separated list of all values
+var FirstCO = "first"                  // Value of the first merged line
+var LastCO = "last"                    // Value of the last merged line
+
+var columnOperations = []string{
+	SumCO, MeanCO, MedianCO, MinCO, MaxCO,
+	CountCO, CountDistinctCO, DistinctCO, CollapseCO,
+	FirstCO, LastCO,
+}
+
+// Aggregate the values of a column in the merged lines
+func aggregate(operation string, values []string) (string, error) {
+	switch operation {
+	case SumCO, MeanCO, MedianCO, MinCO, MaxCO:
+		numbers, err := parseNumbers(values)
+		if err != nil {
+			return "", err
+		}
+		return formatNumber(aggregateNumbers(operation, numbers)), nil
+	case CountCO:
+		return strconv.Itoa(len(values)), nil
+	case CountDistinctCO:
+		return strconv.Itoa(len(distinctValues(values))), nil
+	case DistinctCO:
+		return strings.Join(distinctValues(values), ","), nil
+	case CollapseCO:
+		return strings.Join(values, ","), nil
+	case FirstCO:
+		return values[0], nil
+	case LastCO:
+		return values[len(values)-1], nil
+	default:
+		return "", fmt.Errorf("unknown column operation %s", operation)
+	}
+}
+
+// Aggregate numbers, only numeric operations are supported
+func aggregateNumbers(operation string, numbers []float64) float64 {
+	switch operation {
+	case SumCO, MeanCO:
+		sum := 0.0
+		for _, n := range numbers {
+			sum += n
+		}
+		if operation == MeanCO {
+			return sum / float64(len(numbers))
+		}
+		return sum
+	case MedianCO:
+		sorted := slices.Clone(numbers)
+		slices.Sort(sorted)
+		middle := len(sorted) / 2
+		if len(sorted)%2 == 0 {
+			return (sorted[middle-1] + sorted[middle]) / 2
+		}
+		return sorted[middle]
+	case MinCO:
+		return slices.Min(numbers)
+	default:
+		return slices.Max(numbers)
+	}
+}
+
+// Parse values as numbers
+func parseNumbers(values []string) ([]float64, error) {
+	var numbers []float64
+	for _, v := range values {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("non-numeric value %s", v)
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, nil
+}
+
+// Format number without trailing zeros
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// Distinct values in the order they first appear
+func distinctValues(values []string) []string {
+	var distinct []string
+	for _, v := range values {
+		if !stringInSlice(distinct, v) {
+			distinct = append(distinct, v)
+		}
+	}
+	return distinct
+}
@@ -0,0 +1,115 @@
+package bed
+
+import (
+	"testing"
+)
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing        string
+		operation      string
+		values         []string
+		expectedResult string
+		shouldFail     bool
+	}
+	testCases := []testCase{
+		{
+			testing:        "sum",
+			operation:      SumCO,
+			values:         []string{"1", "2.5", "3"},
+			expectedResult: "6.5",
+		},
+		{
+			testing:        "mean",
+			operation:      MeanCO,
+			values:         []string{"1", "2", "6"},
+			expectedResult: "3",
+		},
+		{
+			testing:        "median, odd number of values",
+			operation:      MedianCO,
+			values:         []string{"9", "1", "2"},
+			expectedResult: "2",
+		},
+		{
+			testing:        "median, even number of values",
+			operation:      MedianCO,
+			values:         []string{"4", "1", "2", "9"},
+			expectedResult: "3",
+		},
+		{
+			testing:        "min",
+			operation:      MinCO,
+			values:         []string{"4", "-1", "2"},
+			expectedResult: "-1",
+		},
+		{
+			testing:        "max",
+			operation:      MaxCO,
+			values:         []string{"4", "-1", "2"},
+			expectedResult: "4",
+		},
+		{
+			testing:        "count",
+			operation:      CountCO,
+			values:         []string{"A", "B", "A"},
+			expectedResult: "3",
+		},
+		{
+			testing:        "count distinct",
+			operation:      CountDistinctCO,
+			values:         []string{"A", "B", "A"},
+			expectedResult: "2",
+		},
+		{
+			testing:        "distinct",
+			operation:      DistinctCO,
+			values:         []string{"B", "A", "B"},
+			expectedResult: "B,A",
+		},
+		{
+			testing:        "collapse",
+			operation:      CollapseCO,
+			values:         []string{"B", "A", "B"},
+			expectedResult: "B,A,B",
+		},
+		{
+			testing:        "first",
+			operation:      FirstCO,
+			values:         []string{"B", "A", "C"},
+			expectedResult: "B",
+		},
+		{
+			testing:        "last",
+			operation:      LastCO,
+			values:         []string{"B", "A", "C"},
+			expectedResult: "C",
+		},
+		{
+			testing:    "numeric operation on non-numeric values",
+			operation:  SumCO,
+			values:     []string{"1", "A"},
+			shouldFail: true,
+		},
+		{
+			testing:    "unknown operation",
+			operation:  "average",
+			values:     []string{"1"},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			result, err := aggregate(tc.operation, tc.values)
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail && tc.expectedResult != result {
+				t.Errorf("expected %s got %s", tc.expectedResult, result)
+			}
+		})
+	}
+}
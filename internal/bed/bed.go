@@ -9,28 +9,99 @@ import (
 // Note that the the user will give the columns with 1-based indexing,
 // but that we convert this to zero-based indexing in .VerifyAndHandle()
 type Bedfile struct {
-	Inputs   []string `arg:"" help:"Bed file path(s). If more than one is provided the files will be joined as if they were one file"`
-	Output   string   `env:"OUTPUT_FILE" short:"o" help:"Path to the output file. If unset the output will be written to stdout"`
-	FastaIdx string   `env:"FASTA_IDX" short:"f" help:"Tab separated file containing at least two columns where the first column contains the chromosome and the second it's size. Compatible with fasta index files, but any text file can be used as long as the file conditions are met"`
+	Inputs           []string `arg:"" optional:"" help:"Bed file path(s). If more than one is provided the files will be joined as if they were one file. Gzip and BGZF compressed files are decompressed automatically. Use - or leave empty to read from stdin"`
+	Output           string   `env:"OUTPUT_FILE" short:"o" help:"Path to the output file. If unset the output will be written to stdout. If the path ends with .gz the output will be BGZF compressed"`
+	Index            string   `env:"INDEX" enum:"${noneIT},${tbiIT},${csiIT}" default:"${noneIT}" help:"Create an index next to the output file. ${noneIT} = no index, ${tbiIT} = tabix index (output.gz.tbi), ${csiIT} = coordinate-sorted index (output.gz.csi), use for chromosomes larger than 512 Mbp. Can only be used when --output ends with .gz"`
+	SplitChr         bool     `env:"SPLIT_CHR" help:"Write one file per chromosome instead of one output file, named using --split-chr-template. Must be used together with --output"`
+	SplitChrTemplate string   `env:"SPLIT_CHR_TEMPLATE" help:"Template for the file names when using --split-chr, where {prefix} is --output without the extension, {chr} is the chromosome and {ext} is the extension of --output (.bed, .bed.gz or .gz). Defaults to {prefix}.{chr}{ext}"`
+	FastaIdx         string   `env:"FASTA_IDX" short:"f" help:"Tab separated file containing at least two columns where the first column contains the chromosome and the second it's size. Compatible with fasta index files, but any text file can be used as long as the file conditions are met"`
+	Validate         string   `env:"VALIDATE" enum:"${noneVT},${failVT},${warnVT},${dropVT}" default:"${noneVT}" help:"Validate the regions against --fasta-idx after reading (and lifting over), checking that their chromosomes are in the fasta index file and that they are within the chromosomes. ${noneVT} = no validation, ${failVT} = fail if there are invalid regions, ${warnVT} = warn about invalid regions, ${dropVT} = remove invalid regions with a warning. All invalid regions are listed"`
 
 	StrandCol int `env:"STRAND_COL" group:"input" help:"The column containing the strand information (1-based column index). If this option is set regions on the same strand will not be merged"`
 	FeatCol   int `env:"FEAT_COL" group:"input" help:"The column containing the feature (e.g. gene id, transcript id etc.) information (1-based column index). If this option is set regions on the same feature will not be merged"`
 
+	ChrPrefix    string `env:"CHR_PREFIX" group:"chromosome names" enum:"${keepCP},${addCP},${stripCP}" default:"${keepCP}" help:"Add or remove the chr prefix of the chromosome names when reading, so that files using different naming conventions can be combined. ${keepCP} = keep the names as they are, ${addCP} = add chr to names without it (1 -> chr1), ${stripCP} = remove chr from the names (chr1 -> 1). Applies to all bed files and the fasta index file"`
+	ChrMT        string `env:"CHR_MT" group:"chromosome names" enum:"${keepCM},${mCM},${mtCM}" default:"${keepCM}" help:"Name of the mitochondrial chromosome, applied after --chr-prefix. ${keepCM} = keep the name as it is, ${mCM} = rename MT to M (chrMT to chrM), ${mtCM} = rename M to MT (chrM to chrMT)"`
+	ChrAlias     string `env:"CHR_ALIAS" group:"chromosome names" help:"Chromosome alias file in UCSC chromAlias format (tab separated names of each chromosome in different naming conventions, with an optional header line starting with # naming the conventions). All names are replaced by the name in the first column, or in --chr-alias-name, before --chr-prefix and --chr-mt are applied"`
+	ChrAliasName string `env:"CHR_ALIAS_NAME" group:"chromosome names" help:"Naming convention in the header of --chr-alias to use for the chromosome names (e.g. ucsc, ensembl or refseq)"`
+
+	Liftover         string  `env:"LIFTOVER" group:"liftover" help:"UCSC chain file (.chain or .chain.gz) used to lift the regions over to another assembly (e.g. hg19ToHg38.over.chain.gz). Lifting over is done right after reading, before padding and merging. The strand (--strand-col) of regions lifted over to the reverse strand is flipped"`
+	LiftoverUnmapped string  `env:"LIFTOVER_UNMAPPED" group:"liftover" help:"Path to a file listing the regions that could not be lifted over, each preceded by a comment line with the reason"`
+	LiftoverMinMatch float64 `env:"LIFTOVER_MIN_MATCH" group:"liftover" default:"0.95" help:"Minimum fraction of the bases in a region that must be lifted over (0-1)"`
+	LiftoverNoSplit  bool    `env:"LIFTOVER_NO_SPLIT" group:"liftover" help:"Do not lift over regions that are aligned by more than one chain (e.g. regions spanning a rearrangement), instead of writing one region per chain"`
+
 	SortType    string   `env:"SORT_TYPE" group:"sorting" enum:"${lexST},${natST},${ccsST},${fidxST}" default:"${lexST}" short:"s" help:"How the bed file should be sorted. ${lexST} = lexicographic sorting (chr: 1 < 10 < 2 < MT < X), ${natST} = natural sorting (chr: 1 < 2 < 10 < MT < X), ${ccsST} = custom chromosome sorting (see --chr-order flag ), ${fidxST} = use ordering from fasta index file (must be used together with --fasta-idx)"`
 	ChrOrder    []string `env:"CHR_ORDER" group:"sorting" help:"Comma separated custom chromosome order, to be used with custom chromosome sorting (--sort-type=ccs). Chromosomes not on the list will be sorted naturally after the ones in the list"`
 	Deduplicate bool     `env:"DEDUPLICATE" group:"sorting" cmd:"" short:"d" help:"Remove duplicated lines"`
 
 	NoMerge bool `env:"NO_MERGE" group:"merging" cmd:"" help:"Do not merge regions"`
+	Stream  bool `env:"STREAM" group:"merging" help:"Merge, pad and write regions while reading them, using only a small amount of memory. The input must be sorted by chromosome according to --sort-type and by start position, bedfusion will fail if an unsorted line is encountered"`
 	Overlap int  `env:"OVERLAP" group:"merging" default:"0" help:"Overlap between regions to be merged. Note that touching regions are merged (e.g. if two regions are on the same chr, and the overlap is they will be merged if one ends at 5 and the other starts at 6). If you don't want touching regions to be merged set overlap to -1"`
 
-	Padding     int    `env:"PADDING" group:"padding" short:"p" help:"Padding in bp. Note that padding is done before merging"`
-	PaddingType string `env:"PADDING_TYPE" group:"padding" enum:"${failPT},${warnPT},${forcePT}" default:"${failPT}" help:"Padding type. safe = bedfusion will fail if it encounters a chromosome not in the fasta index file, ${warnPT} = will only pad regions in the fasta index file and give a warning about chromosomes not in the fasta index file, ${forcePT} = will pad regardless, if --fasta-idx is set there will be given a warning about the chromosomes not in the fasta index file, if --fasta-idx is not set no warnings will be given"`
-	FirstBase   int    `env:"FIRST_BASE" group:"padding" default:"0" help:"The start coordinate of the first base on each chromosome"`
+	MergeCols []int    `env:"MERGE_COLS" group:"merging" help:"Comma separated columns (1-based column index) to aggregate with --merge-ops when merging. Optional columns not in this list are joined as comma separated lists of the distinct values"`
+	MergeOps  []string `env:"MERGE_OPS" group:"merging" help:"Comma separated operations used to aggregate --merge-cols when merging. Either one operation used for all columns or one operation per column. Operations: sum, mean, median, min, max, count, count_distinct, distinct, collapse, first, last"`
+
+	MergeCount  bool `env:"MERGE_COUNT" group:"merging" help:"Append a column with the number of regions that were merged into each region"`
+	MergeCoords bool `env:"MERGE_COORDS" group:"merging" help:"Append a column with the comma separated coordinates (chr:start-stop) of the regions that were merged into each region, before padding"`
+
+	MakeWindows       string `env:"MAKE_WINDOWS" group:"make windows" enum:"${noneMW},${regionsMW},${genomeMW}" default:"${noneMW}" help:"Replace the regions with windows. ${noneMW} = no windows, ${regionsMW} = split each region into windows, ${genomeMW} = split each chromosome in --fasta-idx into windows (no bed files are read). The index of the window and the coordinates (chr:start-stop) of the region or chromosome it was made from are appended to each window"`
+	MakeWindowsSize   int    `env:"MAKE_WINDOWS_SIZE" group:"make windows" help:"Size of the windows in bp, the last window of each region can be shorter"`
+	MakeWindowsStep   int    `env:"MAKE_WINDOWS_STEP" group:"make windows" help:"Distance in bp between the start of two windows, use a step smaller than --make-windows-size for sliding windows. Defaults to --make-windows-size"`
+	MakeWindowsNumber int    `env:"MAKE_WINDOWS_NUMBER" group:"make windows" help:"Number of windows of equal size to split each region or chromosome into, instead of using --make-windows-size"`
+
+	Intersect         string  `env:"INTERSECT" group:"intersect" help:"Bed file to intersect with. Only regions overlapping regions in this file are kept. If --strand-col is set the file must have the strand in the same column, and only regions on the same strand are considered overlapping"`
+	IntersectReport   string  `env:"INTERSECT_REPORT" group:"intersect" enum:"${overlapIR},${overlapBIR},${aIR},${abIR},${uniqueIR}" default:"${overlapIR}" help:"What to report for each overlap. ${overlapIR} = the overlapping part of the region, ${overlapBIR} = the overlapping part of the region followed by the region in --intersect, ${aIR} = the whole region, ${abIR} = the whole region followed by the region in --intersect, ${uniqueIR} = the whole region once if it has any overlap"`
+	IntersectFraction float64 `env:"INTERSECT_FRACTION" group:"intersect" default:"0" help:"Minimum overlap required as a fraction of the region (0-1). If 0 an overlap of one base is enough"`
+
+	Subtract         string  `env:"SUBTRACT" group:"subtract" help:"Bed file with regions to subtract. The parts of the regions overlapping regions in this file are removed, and regions are split if necessary"`
+	SubtractWhole    bool    `env:"SUBTRACT_WHOLE" group:"subtract" help:"Remove the whole region if it overlaps a region in --subtract"`
+	SubtractFraction float64 `env:"SUBTRACT_FRACTION" group:"subtract" default:"0" help:"Minimum overlap required for a region in --subtract to be subtracted, as a fraction of the region (0-1). If 0 an overlap of one base is enough"`
+	SubtractGrouped  bool    `env:"SUBTRACT_GROUPED" group:"subtract" help:"Only subtract regions on the same strand (--strand-col) and feature (--feat-col). The file must have the strand and feature in the same columns"`
+
+	Complement bool `env:"COMPLEMENT" group:"complement" help:"Replace the regions with the regions in the genome that are not covered by them. Chromosomes in the fasta index file without any regions are included as a whole. Must be used together with --fasta-idx"`
 
-	Header       []string `kong:"-"`
-	Lines        []Line   `kong:"-"`
-	chrOrderMap  map[string]int
-	chrLengthMap map[string]int
+	Closest          string `env:"CLOSEST" group:"closest" help:"Bed file to find the closest regions in. The closest region and the distance to it is appended to each region. The distance is 0 for overlapping regions, and negative for regions upstream, according to the strand if --strand-col is set. If there is no region on the same chromosome -1 is used as distance"`
+	ClosestTies      string `env:"CLOSEST_TIES" group:"closest" enum:"${allCT},${firstCT},${lastCT}" default:"${allCT}" help:"How to handle closest regions with the same distance. ${allCT} = report all, ${firstCT} = report the first, ${lastCT} = report the last"`
+	ClosestDirection string `env:"CLOSEST_DIRECTION" group:"closest" enum:"${bothCD},${upstreamCD},${downstreamCD}" default:"${bothCD}" help:"Direction to look for the closest regions in. ${bothCD} = both upstream and downstream, ${upstreamCD} = only upstream, ${downstreamCD} = only downstream"`
+	ClosestNoOverlap bool   `env:"CLOSEST_NO_OVERLAP" group:"closest" help:"Ignore overlapping regions when looking for the closest regions"`
+
+	Window       string `env:"WINDOW" group:"window" help:"Bed file to search for regions within a window around each region. Each region is reported followed by each region in this file that overlaps the window. The regions themselves are not padded"`
+	WindowSize   int    `env:"WINDOW_SIZE" group:"window" default:"1000" help:"Size of the window in bp added to both sides of the regions"`
+	WindowLeft   *int   `env:"WINDOW_LEFT" group:"window" help:"Size of the window in bp added to the left of the regions, overrides --window-size. If --window-strand is set this is upstream"`
+	WindowRight  *int   `env:"WINDOW_RIGHT" group:"window" help:"Size of the window in bp added to the right of the regions, overrides --window-size. If --window-strand is set this is downstream"`
+	WindowStrand bool   `env:"WINDOW_STRAND" group:"window" help:"Use the strand of the regions (--strand-col) so that --window-left is upstream and --window-right is downstream"`
+
+	Scatter        int    `env:"SCATTER" group:"scatter" help:"Split the output into this number of files of roughly equal total size (bp), for running jobs in parallel. The files are numbered (e.g. 01.bed, 02.bed, ...) and written to --scatter-dir"`
+	ScatterDir     string `env:"SCATTER_DIR" group:"scatter" help:"Directory to write the scattered files to, it is created if it does not exist"`
+	ScatterSplit   bool   `env:"SCATTER_SPLIT" group:"scatter" help:"Split regions at the borders between the files, so that the files have the same total size"`
+	ScatterKeepChr bool   `env:"SCATTER_KEEP_CHR" group:"scatter" help:"Never split a chromosome across files, the regions of each chromosome are written to the same file"`
+
+	PaddingInput  string `name:"padding" env:"PADDING" group:"padding" short:"p" type:"padding" placeholder:"INT|FLOATx" help:"Padding in bp, or as a fraction of the length of each region if it ends with x (e.g. 0.1x). Note that padding is done before merging"`
+	PaddingCol    int    `env:"PADDING_COL" group:"padding" help:"The column containing the padding of each region (1-based column index), in bp or as a fraction of the region length (e.g. 0.1x). Regions where the column is empty or . are padded with --padding"`
+	PaddingMin    int    `env:"PADDING_MIN" group:"padding" help:"Minimum padding in bp when padding with a fraction of the region length, from --padding or --padding-col"`
+	PaddingMax    int    `env:"PADDING_MAX" group:"padding" help:"Maximum padding in bp when padding with a fraction of the region length, from --padding or --padding-col. If 0 there is no maximum"`
+	PadLeft       *int   `env:"PAD_LEFT" group:"padding" help:"Padding in bp added to the left of the regions, overrides --padding"`
+	PadRight      *int   `env:"PAD_RIGHT" group:"padding" help:"Padding in bp added to the right of the regions, overrides --padding"`
+	PadUpstream   *int   `env:"PAD_UPSTREAM" group:"padding" help:"Padding in bp added upstream of the regions according to the strand (--strand-col). Overrides --padding and --pad-left/--pad-right for regions on the + or - strand"`
+	PadDownstream *int   `env:"PAD_DOWNSTREAM" group:"padding" help:"Padding in bp added downstream of the regions according to the strand (--strand-col). Overrides --padding and --pad-left/--pad-right for regions on the + or - strand"`
+	MinSize       int    `env:"MIN_SIZE" group:"padding" help:"Minimum size in bp of the regions. Regions shorter than this are extended evenly around their centre, in addition to any other padding. Regions that are long enough are not extended"`
+	Shrink        int    `env:"SHRINK" group:"padding" help:"Remove this many bp from both ends of the regions. Regions that would disappear are shrunk to the base in their middle instead. Shrinking is done before shifting and padding"`
+	Shift         int    `env:"SHIFT" group:"padding" help:"Move the regions this many bp to the right, or to the left if negative. Regions moved beyond the chromosome borders are clipped, keeping at least one base. Shifting is done before padding"`
+	ShiftStrand   bool   `env:"SHIFT_STRAND" group:"padding" help:"Use the strand of the regions (--strand-col) so that --shift moves the regions downstream, or upstream if negative"`
+	Flank         string `env:"FLANK" group:"padding" enum:"${noneFT},${bothFT},${leftFT},${rightFT},${upstreamFT},${downstreamFT}" default:"${noneFT}" help:"Replace the regions with their flanks, using the padding as the size of the flanks. ${noneFT} = no flanking, ${bothFT} = flanks on both sides, ${leftFT} = left flank, ${rightFT} = right flank, ${upstreamFT} = upstream flank, ${downstreamFT} = downstream flank (upstream and downstream according to the strand, must be used together with --strand-col)"`
+	PaddingType   string `env:"PADDING_TYPE" group:"padding" enum:"${failPT},${warnPT},${forcePT}" default:"${failPT}" help:"Padding type. safe = bedfusion will fail if it encounters a chromosome not in the fasta index file, ${warnPT} = will only pad regions in the fasta index file and give a warning about chromosomes not in the fasta index file, ${forcePT} = will pad regardless, if --fasta-idx is set there will be given a warning about the chromosomes not in the fasta index file, if --fasta-idx is not set no warnings will be given"`
+	FirstBase     int    `env:"FIRST_BASE" group:"padding" default:"0" help:"The start coordinate of the first base on each chromosome"`
+
+	ClippedReport  string `env:"CLIPPED_REPORT" group:"padding" help:"Path to a file listing the regions that were clipped at the chromosome borders when padding, with the coordinates before and after padding and the number of bases clipped on each side"`
+	OriginalCoords bool   `env:"ORIGINAL_COORDS" group:"padding" help:"Append the start and stop of the regions before padding as two columns. When merging the smallest start and largest stop of the merged regions are appended, followed by a column with the comma separated coordinates (chr:start-stop) of the merged regions"`
+
+	Padding         int      `kong:"-"`
+	PaddingFraction float64  `kong:"-"`
+	Header          []string `kong:"-"`
+	Lines           []Line   `kong:"-"`
+	chrOrderMap     map[string]int
+	chrLengthMap    map[string]int
+	chrAliasMap     map[string]string
 }
 
 type Line struct {
@@ -44,22 +115,113 @@ type Line struct {
 
 // Verifies and handles Bedfile input
 func (bf *Bedfile) VerifyAndHandle() error {
+	if err := bf.verifyAndHandleInputs(); err != nil {
+		return err
+	}
 	if err := bf.verifyAndHandleColumns(); err != nil {
 		return err
 	}
+	if err := bf.verifyAndHandlePadding(); err != nil {
+		return err
+	}
 	if err := bf.verifyFastaIdxCombinations(); err != nil {
 		return err
 	}
 	if err := bf.verifyFirstBase(); err != nil {
 		return err
 	}
+	if err := bf.verifyIndex(); err != nil {
+		return err
+	}
+	if err := bf.verifySplitChr(); err != nil {
+		return err
+	}
+	if err := bf.verifyChrAlias(); err != nil {
+		return err
+	}
+	if err := bf.verifyLiftover(); err != nil {
+		return err
+	}
+	if err := bf.verifyStream(); err != nil {
+		return err
+	}
+	if err := bf.verifyMergeCount(); err != nil {
+		return err
+	}
+	if err := bf.verifyMakeWindows(); err != nil {
+		return err
+	}
+	if err := bf.verifyScatter(); err != nil {
+		return err
+	}
+	if err := bf.verifyIntersect(); err != nil {
+		return err
+	}
+	if err := bf.verifySubtract(); err != nil {
+		return err
+	}
+	if err := bf.verifyWindow(); err != nil {
+		return err
+	}
+	if err := bf.verifyStrandPadding(); err != nil {
+		return err
+	}
+	if err := bf.verifyMinSize(); err != nil {
+		return err
+	}
+	if err := bf.verifyShrinkAndShift(); err != nil {
+		return err
+	}
+	if err := bf.verifyOriginalCoords(); err != nil {
+		return err
+	}
+	if err := bf.verifyClippedReport(); err != nil {
+		return err
+	}
+	if err := bf.verifyFlank(); err != nil {
+		return err
+	}
 	bf.handleCCSSorting()
 	bf.cleanPaths()
 	return nil
 }
 
-// Verifies Strand and Feat columns and subtracts 1 to be able to use zero-based indexing
+// Read from stdin if no inputs are given (unless making windows
+// from the genome), and verify that stdin
+// is not used more than once, including the bed files used by
+// intersect, subtract, closest and window
+func (bf *Bedfile) verifyAndHandleInputs() error {
+	// When making windows from the genome no bed files are read
+	if bf.MakeWindows == GenomeMW {
+		if len(bf.Inputs) > 0 {
+			return fmt.Errorf("--make-windows=%s can not be used together with bed files: %v", bf.MakeWindows, bf.Inputs)
+		}
+	} else if len(bf.Inputs) == 0 {
+		bf.Inputs = []string{stdinPath}
+	}
+	nrOfStdin := 0
+	for _, input := range append([]string{bf.ChrAlias, bf.Liftover, bf.Intersect, bf.Subtract, bf.Closest, bf.Window}, bf.Inputs...) {
+		if input == stdinPath {
+			nrOfStdin++
+		}
+	}
+	if nrOfStdin > 1 {
+		return fmt.Errorf("stdin (%s) can only be used once as input", stdinPath)
+	}
+	return nil
+}
+
+// Verifies Padding, Strand, Feat and Merge columns and subtracts 1 to be able to use zero-based indexing
 func (bf *Bedfile) verifyAndHandleColumns() error {
+	if bf.PaddingCol != 0 {
+		if bf.PaddingCol < stopIdx+2 {
+			return fmt.Errorf("--padding-col is at position less than 4: %d", bf.PaddingCol)
+		}
+		if bf.PaddingCol == bf.StrandCol || bf.PaddingCol == bf.FeatCol {
+			return fmt.Errorf("--padding-col can not be set to the same column as --strand-col or --feat-col: %d", bf.PaddingCol)
+		}
+		bf.PaddingCol--
+	}
 	if bf.StrandCol != 0 {
 		if bf.StrandCol < stopIdx+1 {
 			return fmt.Errorf("--strand-col is at position less than 3: %d", bf.StrandCol)
@@ -75,15 +237,75 @@ func (bf *Bedfile) verifyAndHandleColumns() error {
 		}
 		bf.FeatCol--
 	}
+	for i, col := range bf.MergeCols {
+		if col < stopIdx+2 {
+			return fmt.Errorf("--merge-cols contains a column at position less than 4: %d", col)
+		}
+		bf.MergeCols[i]--
+	}
+	return bf.verifyMergeOps()
+}
+
+// Verify that the merge operations are known and match the merge columns
+func (bf Bedfile) verifyMergeOps() error {
+	if len(bf.MergeOps) > 0 && len(bf.MergeCols) == 0 {
+		return fmt.Errorf("--merge-ops must be used together with --merge-cols")
+	}
+	if len(bf.MergeCols) > 0 && len(bf.MergeOps) == 0 {
+		return fmt.Errorf("--merge-cols must be used together with --merge-ops")
+	}
+	if len(bf.MergeOps) > 1 && len(bf.MergeOps) != len(bf.MergeCols) {
+		return fmt.Errorf("the number of --merge-ops must be 1 or equal to the number of --merge-cols: %d != %d",
+			len(bf.MergeOps), len(bf.MergeCols))
+	}
+	for _, op := range bf.MergeOps {
+		if !stringInSlice(columnOperations, op) {
+			return fmt.Errorf("unknown merge operation %s, must be one of %v", op, columnOperations)
+		}
+	}
+	return nil
+}
+
+// Verifies the padding input and converts it to padding in bp
+// or a fraction of the region length
+func (bf *Bedfile) verifyAndHandlePadding() error {
+	if bf.PaddingInput != "" {
+		var err error
+		bf.Padding, bf.PaddingFraction, err = parsePadding(bf.PaddingInput)
+		if err != nil {
+			return fmt.Errorf("--padding %w", err)
+		}
+	}
+	if bf.PaddingMin < 0 || bf.PaddingMax < 0 {
+		return fmt.Errorf("--padding-min and --padding-max can not be negative: %d, %d", bf.PaddingMin, bf.PaddingMax)
+	}
+	if (bf.PaddingMin != 0 || bf.PaddingMax != 0) && bf.PaddingFraction == 0 && bf.PaddingCol == 0 {
+		return fmt.Errorf("--padding-min and --padding-max must be used together with a fractional --padding (e.g. 0.1x) or --padding-col")
+	}
+	if bf.PaddingMax != 0 && bf.PaddingMin > bf.PaddingMax {
+		return fmt.Errorf("--padding-min can not be larger than --padding-max: %d > %d", bf.PaddingMin, bf.PaddingMax)
+	}
 	return nil
 }
 
 // Verify fasta-idx combinations
 func (bf Bedfile) verifyFastaIdxCombinations() error {
 	// Verify that fasta-idx is set if padding is selected
-	if bf.Padding != 0 && bf.PaddingType != "force" && bf.FastaIdx == "" {
+	if bf.paddingSizeSelected() && bf.PaddingType != "force" && bf.FastaIdx == "" {
 		return fmt.Errorf("--padding-type=%s must be used together with --fasta-idx", bf.PaddingType)
 	}
+	// Verify that fasta-idx is set if validation is selected
+	if bf.ValidateSelected() && bf.FastaIdx == "" {
+		return fmt.Errorf("--validate=%s must be used together with --fasta-idx", bf.Validate)
+	}
+	// Verify that fasta-idx is set if making windows from the genome
+	if bf.MakeWindows == GenomeMW && bf.FastaIdx == "" {
+		return fmt.Errorf("--make-windows=%s must be used together with --fasta-idx", bf.MakeWindows)
+	}
+	// Verify that fasta-idx is set if complement is selected
+	if bf.Complement && bf.FastaIdx == "" {
+		return fmt.Errorf("--complement must be used together with --fasta-idx")
+	}
 	// Verify that fasta-idx is set if sort type is fastaidx
 	if bf.SortType == FidxST && bf.FastaIdx == "" {
 		return fmt.Errorf("--sort-type=%s must be used together with --fasta-idx", bf.SortType)
@@ -99,6 +321,280 @@ func (bf *Bedfile) verifyFirstBase() error {
 	return nil
 }
 
+// Verify that the output can be indexed
+func (bf Bedfile) verifyIndex() error {
+	if bf.Index != "" && bf.Index != NoneIT && !strings.HasSuffix(bf.Output, ".gz") {
+		return fmt.Errorf("--index=%s can only be used when --output ends with .gz: %q", bf.Index, bf.Output)
+	}
+	return nil
+}
+
+// Verify that the alias naming convention is used together with an alias file
+func (bf Bedfile) verifyChrAlias() error {
+	if bf.ChrAliasName != "" && bf.ChrAlias == "" {
+		return fmt.Errorf("--chr-alias-name must be used together with --chr-alias")
+	}
+	return nil
+}
+
+// Verify that the liftover options are used together with a chain file
+func (bf Bedfile) verifyLiftover() error {
+	if bf.Liftover == "" {
+		if bf.LiftoverUnmapped != "" || bf.LiftoverNoSplit {
+			return fmt.Errorf("--liftover-unmapped and --liftover-no-split must be used together with --liftover")
+		}
+		return nil
+	}
+	if bf.LiftoverMinMatch < 0 || bf.LiftoverMinMatch > 1 {
+		return fmt.Errorf("--liftover-min-match must be between 0 and 1: %g", bf.LiftoverMinMatch)
+	}
+	return nil
+}
+
+// Verify that the output can be split by chromosome
+func (bf Bedfile) verifySplitChr() error {
+	if !bf.SplitChr {
+		if bf.SplitChrTemplate != "" {
+			return fmt.Errorf("--split-chr-template must be used together with --split-chr")
+		}
+		return nil
+	}
+	if bf.Output == "" {
+		return fmt.Errorf("--split-chr must be used together with --output")
+	}
+	if bf.SplitChrTemplate != "" && !strings.Contains(bf.SplitChrTemplate, "{chr}") {
+		return fmt.Errorf("--split-chr-template must contain {chr}: %q", bf.SplitChrTemplate)
+	}
+	if path := bf.splitChrPath("chr"); bf.Index != "" && bf.Index != NoneIT && !strings.HasSuffix(path, ".gz") {
+		return fmt.Errorf("--index=%s can only be used when the files from --split-chr-template end with .gz: %q", bf.Index, path)
+	}
+	return nil
+}
+
+// Verify that the selected options can be used when streaming
+func (bf Bedfile) verifyStream() error {
+	if bf.Stream && bf.Deduplicate {
+		return fmt.Errorf("--stream can not be used together with --deduplicate")
+	}
+	if bf.Stream && bf.MakeWindowsSelected() {
+		return fmt.Errorf("--stream can not be used together with --make-windows")
+	}
+	if bf.Stream && bf.Liftover != "" {
+		return fmt.Errorf("--stream can not be used together with --liftover")
+	}
+	if bf.Stream && bf.SplitChr {
+		return fmt.Errorf("--stream can not be used together with --split-chr")
+	}
+	if bf.Stream && bf.Scatter != 0 {
+		return fmt.Errorf("--stream can not be used together with --scatter")
+	}
+	if bf.Stream && bf.Intersect != "" {
+		return fmt.Errorf("--stream can not be used together with --intersect")
+	}
+	if bf.Stream && bf.Subtract != "" {
+		return fmt.Errorf("--stream can not be used together with --subtract")
+	}
+	if bf.Stream && bf.Complement {
+		return fmt.Errorf("--stream can not be used together with --complement")
+	}
+	if bf.Stream && bf.Closest != "" {
+		return fmt.Errorf("--stream can not be used together with --closest")
+	}
+	if bf.Stream && bf.Window != "" {
+		return fmt.Errorf("--stream can not be used together with --window")
+	}
+	if bf.Stream && bf.FlankSelected() {
+		return fmt.Errorf("--stream can not be used together with --flank")
+	}
+	// Padding that depends on the length or a column of the regions
+	// can change the order of the starts, which is only checked
+	// before padding when streaming
+	if bf.Stream && bf.PaddingFraction != 0 {
+		return fmt.Errorf("--stream can not be used together with fractional --padding")
+	}
+	if bf.Stream && bf.PaddingCol != 0 {
+		return fmt.Errorf("--stream can not be used together with --padding-col")
+	}
+	if bf.Stream && bf.MinSize != 0 {
+		return fmt.Errorf("--stream can not be used together with --min-size")
+	}
+	if bf.Stream && bf.Shrink != 0 {
+		return fmt.Errorf("--stream can not be used together with --shrink")
+	}
+	if bf.Stream && bf.PadUpstream != nil {
+		return fmt.Errorf("--stream can not be used together with --pad-upstream")
+	}
+	if bf.Stream && bf.PadDownstream != nil {
+		return fmt.Errorf("--stream can not be used together with --pad-downstream")
+	}
+	if bf.Stream && bf.ShiftStrand {
+		return fmt.Errorf("--stream can not be used together with --shift-strand")
+	}
+	return nil
+}
+
+// Verify that the merged regions can be counted
+func (bf Bedfile) verifyMergeCount() error {
+	if bf.NoMerge && bf.MergeCount {
+		return fmt.Errorf("--merge-count can not be used together with --no-merge")
+	}
+	if bf.NoMerge && bf.MergeCoords {
+		return fmt.Errorf("--merge-coords can not be used together with --no-merge")
+	}
+	return nil
+}
+
+// Verify that either the size or the number of windows is set
+func (bf Bedfile) verifyMakeWindows() error {
+	if !bf.MakeWindowsSelected() {
+		if bf.MakeWindowsSize != 0 || bf.MakeWindowsStep != 0 || bf.MakeWindowsNumber != 0 {
+			return fmt.Errorf("--make-windows-size, --make-windows-step and --make-windows-number must be used together with --make-windows")
+		}
+		return nil
+	}
+	if bf.MakeWindowsSize < 0 || bf.MakeWindowsStep < 0 || bf.MakeWindowsNumber < 0 {
+		return fmt.Errorf("--make-windows-size, --make-windows-step and --make-windows-number can not be negative")
+	}
+	if (bf.MakeWindowsSize == 0) == (bf.MakeWindowsNumber == 0) {
+		return fmt.Errorf("--make-windows=%s must be used together with either --make-windows-size or --make-windows-number", bf.MakeWindows)
+	}
+	if bf.MakeWindowsStep != 0 && bf.MakeWindowsSize == 0 {
+		return fmt.Errorf("--make-windows-step must be used together with --make-windows-size")
+	}
+	return nil
+}
+
+// Verify that the number of files and the directory
+// to write them to are set together
+func (bf Bedfile) verifyScatter() error {
+	if bf.Scatter < 0 {
+		return fmt.Errorf("--scatter can not be negative")
+	}
+	if bf.Scatter == 0 {
+		if bf.ScatterDir != "" || bf.ScatterSplit || bf.ScatterKeepChr {
+			return fmt.Errorf("--scatter-dir, --scatter-split and --scatter-keep-chr must be used together with --scatter")
+		}
+		return nil
+	}
+	if bf.ScatterDir == "" {
+		return fmt.Errorf("--scatter must be used together with --scatter-dir")
+	}
+	if bf.Output != "" {
+		return fmt.Errorf("--scatter can not be used together with --output")
+	}
+	if bf.ScatterSplit && bf.ScatterKeepChr {
+		return fmt.Errorf("--scatter-split can not be used together with --scatter-keep-chr")
+	}
+	return nil
+}
+
+// Verify intersect input
+func (bf Bedfile) verifyIntersect() error {
+	if bf.IntersectFraction < 0 || bf.IntersectFraction > 1 {
+		return fmt.Errorf("--intersect-fraction must be between 0 and 1: %g", bf.IntersectFraction)
+	}
+	return nil
+}
+
+// Verify subtract input
+func (bf Bedfile) verifySubtract() error {
+	if bf.SubtractFraction < 0 || bf.SubtractFraction > 1 {
+		return fmt.Errorf("--subtract-fraction must be between 0 and 1: %g", bf.SubtractFraction)
+	}
+	return nil
+}
+
+// Verify window input
+func (bf Bedfile) verifyWindow() error {
+	if bf.WindowSize < 0 {
+		return fmt.Errorf("--window-size can not be negative: %d", bf.WindowSize)
+	}
+	if bf.WindowLeft != nil && *bf.WindowLeft < 0 {
+		return fmt.Errorf("--window-left can not be negative: %d", *bf.WindowLeft)
+	}
+	if bf.WindowRight != nil && *bf.WindowRight < 0 {
+		return fmt.Errorf("--window-right can not be negative: %d", *bf.WindowRight)
+	}
+	if bf.WindowStrand && bf.StrandCol == 0 {
+		return fmt.Errorf("--window-strand must be used together with --strand-col")
+	}
+	return nil
+}
+
+// Verify that the strand is known when padding upstream or downstream
+func (bf Bedfile) verifyStrandPadding() error {
+	if bf.PadUpstream != nil && bf.StrandCol == 0 {
+		return fmt.Errorf("--pad-upstream must be used together with --strand-col")
+	}
+	if bf.PadDownstream != nil && bf.StrandCol == 0 {
+		return fmt.Errorf("--pad-downstream must be used together with --strand-col")
+	}
+	return nil
+}
+
+// Verify shrink and shift input
+func (bf Bedfile) verifyShrinkAndShift() error {
+	if bf.Shrink < 0 {
+		return fmt.Errorf("--shrink can not be negative, use --padding to extend regions: %d", bf.Shrink)
+	}
+	if bf.ShiftStrand && bf.StrandCol == 0 {
+		return fmt.Errorf("--shift-strand must be used together with --strand-col")
+	}
+	if bf.FlankSelected() && (bf.Shrink != 0 || bf.Shift != 0) {
+		return fmt.Errorf("--flank can not be used together with --shrink or --shift")
+	}
+	return nil
+}
+
+// Verify that the original coordinates differ from the
+// regions and are not already appended
+func (bf Bedfile) verifyOriginalCoords() error {
+	if !bf.OriginalCoords {
+		return nil
+	}
+	if bf.NoMerge && !bf.PaddingSelected() {
+		return fmt.Errorf("--original-coords must be used together with padding or merging")
+	}
+	if bf.FlankSelected() {
+		return fmt.Errorf("--original-coords can not be used together with --flank")
+	}
+	if bf.MergeCoords {
+		return fmt.Errorf("--original-coords can not be used together with --merge-coords, the coordinates of the merged regions are already included")
+	}
+	return nil
+}
+
+// Verify that there is padding to report clipping for
+func (bf Bedfile) verifyClippedReport() error {
+	if bf.ClippedReport != "" && !bf.paddingSizeSelected() {
+		return fmt.Errorf("--clipped-report must be used together with a padding option (e.g. --padding)")
+	}
+	return nil
+}
+
+// Verify minimum size input
+func (bf Bedfile) verifyMinSize() error {
+	if bf.MinSize < 0 {
+		return fmt.Errorf("--min-size can not be negative: %d", bf.MinSize)
+	}
+	return nil
+}
+
+// Verify that the size of the flanks is set, and that the
+// strand is known when flanking upstream or downstream
+func (bf Bedfile) verifyFlank() error {
+	if !bf.FlankSelected() {
+		return nil
+	}
+	if !bf.paddingSizeSelected() {
+		return fmt.Errorf("--flank=%s must be used together with a padding option (e.g. --padding)", bf.Flank)
+	}
+	if (bf.Flank == UpstreamFT || bf.Flank == DownstreamFT) && bf.StrandCol == 0 {
+		return fmt.Errorf("--flank=%s must be used together with --strand-col", bf.Flank)
+	}
+	return nil
+}
+
 // Create chr order map
 func (bf *Bedfile) handleCCSSorting() {
 	// Creating chromosome order map only if from custom chromosome
@@ -131,4 +627,31 @@ func (bf *Bedfile) cleanPaths() {
 	if bf.FastaIdx != "" {
 		bf.FastaIdx = filepath.Clean(bf.FastaIdx)
 	}
+	if bf.ChrAlias != "" {
+		bf.ChrAlias = filepath.Clean(bf.ChrAlias)
+	}
+	if bf.Liftover != "" {
+		bf.Liftover = filepath.Clean(bf.Liftover)
+	}
+	if bf.LiftoverUnmapped != "" {
+		bf.LiftoverUnmapped = filepath.Clean(bf.LiftoverUnmapped)
+	}
+	if bf.Intersect != "" {
+		bf.Intersect = filepath.Clean(bf.Intersect)
+	}
+	if bf.Subtract != "" {
+		bf.Subtract = filepath.Clean(bf.Subtract)
+	}
+	if bf.Closest != "" {
+		bf.Closest = filepath.Clean(bf.Closest)
+	}
+	if bf.Window != "" {
+		bf.Window = filepath.Clean(bf.Window)
+	}
+	if bf.ClippedReport != "" {
+		bf.ClippedReport = filepath.Clean(bf.ClippedReport)
+	}
+	if bf.ScatterDir != "" {
+		bf.ScatterDir = filepath.Clean(bf.ScatterDir)
+	}
 }
@@ -13,6 +13,133 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+func TestVerifyAndHandleInputs(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing     string
+		bed         Bedfile
+		expectedBed Bedfile
+		shouldFail  bool
+	}
+	testCases := []testCase{
+		{
+			testing: "file inputs",
+			bed: Bedfile{
+				Inputs: []string{"/some/path/test.bed", "/some/path/test2.bed"},
+			},
+			expectedBed: Bedfile{
+				Inputs: []string{"/some/path/test.bed", "/some/path/test2.bed"},
+			},
+		},
+		{
+			testing: "no inputs",
+			bed:     Bedfile{},
+			expectedBed: Bedfile{
+				Inputs: []string{"-"},
+			},
+		},
+		{
+			testing: "stdin together with file input",
+			bed: Bedfile{
+				Inputs: []string{"/some/path/test.bed", "-"},
+			},
+			expectedBed: Bedfile{
+				Inputs: []string{"/some/path/test.bed", "-"},
+			},
+		},
+		{
+			testing: "stdin used twice",
+			bed: Bedfile{
+				Inputs: []string{"-", "/some/path/test.bed", "-"},
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stdin used as both alias file and input",
+			bed: Bedfile{
+				Inputs:   []string{"-"},
+				ChrAlias: "-",
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stdin used as both chain file and input",
+			bed: Bedfile{
+				Inputs:   []string{"-"},
+				Liftover: "-",
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "make windows from genome without inputs",
+			bed: Bedfile{
+				MakeWindows: GenomeMW,
+			},
+			expectedBed: Bedfile{
+				MakeWindows: GenomeMW,
+			},
+		},
+		{
+			testing: "make windows from genome with inputs",
+			bed: Bedfile{
+				Inputs:      []string{"/some/path/test.bed"},
+				MakeWindows: GenomeMW,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "intersect from stdin",
+			bed: Bedfile{
+				Inputs:    []string{"/some/path/test.bed"},
+				Intersect: "-",
+			},
+			expectedBed: Bedfile{
+				Inputs:    []string{"/some/path/test.bed"},
+				Intersect: "-",
+			},
+		},
+		{
+			testing: "intersect from stdin and no inputs",
+			bed: Bedfile{
+				Intersect: "-",
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "subtract and input from stdin",
+			bed: Bedfile{
+				Inputs:   []string{"-"},
+				Subtract: "-",
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "closest and intersect from stdin",
+			bed: Bedfile{
+				Inputs:    []string{"/some/path/test.bed"},
+				Intersect: "-",
+				Closest:   "-",
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyAndHandleInputs()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail {
+				if diff := deep.Equal(tc.expectedBed, tc.bed); diff != nil {
+					t.Error("expected VS received bed", diff)
+				}
+			}
+		})
+	}
+}
+
 func TestVerifyAndHandleColumns(t *testing.T) {
 	t.Parallel()
 	type testCase struct {
@@ -84,6 +211,105 @@ func TestVerifyAndHandleColumns(t *testing.T) {
 			},
 			shouldFail: true,
 		},
+		{
+			testing: "correct input with padding col",
+			bed: Bedfile{
+				Inputs:     []string{"/some/path/test.bed"},
+				StrandCol:  4,
+				PaddingCol: 5,
+			},
+			expectedBed: Bedfile{
+				Inputs:     []string{"/some/path/test.bed"},
+				StrandCol:  3,
+				PaddingCol: 4,
+			},
+		},
+		{
+			testing: "padding col less than 4",
+			bed: Bedfile{
+				Inputs:     []string{"/some/path/test.bed"},
+				PaddingCol: 3,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "overlapping padding and feat cols",
+			bed: Bedfile{
+				Inputs:     []string{"/some/path/test.bed"},
+				FeatCol:    4,
+				PaddingCol: 4,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "correct input with merge cols and one merge op",
+			bed: Bedfile{
+				Inputs:    []string{"/some/path/test.bed"},
+				MergeCols: []int{4, 5},
+				MergeOps:  []string{SumCO},
+			},
+			expectedBed: Bedfile{
+				Inputs:    []string{"/some/path/test.bed"},
+				MergeCols: []int{3, 4},
+				MergeOps:  []string{SumCO},
+			},
+		},
+		{
+			testing: "correct input with one merge op per merge col",
+			bed: Bedfile{
+				Inputs:    []string{"/some/path/test.bed"},
+				MergeCols: []int{4, 5},
+				MergeOps:  []string{MeanCO, CollapseCO},
+			},
+			expectedBed: Bedfile{
+				Inputs:    []string{"/some/path/test.bed"},
+				MergeCols: []int{3, 4},
+				MergeOps:  []string{MeanCO, CollapseCO},
+			},
+		},
+		{
+			testing: "merge col less than 4",
+			bed: Bedfile{
+				Inputs:    []string{"/some/path/test.bed"},
+				MergeCols: []int{3},
+				MergeOps:  []string{SumCO},
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "merge cols without merge ops",
+			bed: Bedfile{
+				Inputs:    []string{"/some/path/test.bed"},
+				MergeCols: []int{4},
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "merge ops without merge cols",
+			bed: Bedfile{
+				Inputs:   []string{"/some/path/test.bed"},
+				MergeOps: []string{SumCO},
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "number of merge ops does not match merge cols",
+			bed: Bedfile{
+				Inputs:    []string{"/some/path/test.bed"},
+				MergeCols: []int{4, 5, 6},
+				MergeOps:  []string{SumCO, MaxCO},
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "unknown merge op",
+			bed: Bedfile{
+				Inputs:    []string{"/some/path/test.bed"},
+				MergeCols: []int{4},
+				MergeOps:  []string{"average"},
+			},
+			shouldFail: true,
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -178,6 +404,61 @@ func TestVerifyFastaIdxCombinations(t *testing.T) {
 				SortType: FidxST,
 			},
 		},
+		{
+			testing: "complement and fasta-idx selected",
+			bed: Bedfile{
+				Inputs:     []string{"/some/path/test.bed"},
+				FastaIdx:   "/some/fasta/idx/file.fasta.fai",
+				Complement: true,
+			},
+		},
+		{
+			testing: "pad left of type safe selected, but missing fasta index file",
+			bed: Bedfile{
+				Inputs:      []string{"/some/path/test.bed"},
+				PadLeft:     new(int),
+				PaddingType: SafePT,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "complement selected, but missing fasta index file",
+			bed: Bedfile{
+				Inputs:     []string{"/some/path/test.bed"},
+				Complement: true,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "validate and fasta-idx selected",
+			bed: Bedfile{
+				Inputs:   []string{"/some/path/test.bed"},
+				Validate: FailVT,
+				FastaIdx: "/some/fasta/idx/file.fasta.fai",
+			},
+		},
+		{
+			testing: "validate selected, but missing fasta index file",
+			bed: Bedfile{
+				Inputs:   []string{"/some/path/test.bed"},
+				Validate: DropVT,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "make windows from genome and fasta-idx selected",
+			bed: Bedfile{
+				MakeWindows: GenomeMW,
+				FastaIdx:    "/some/fasta/idx/file.fasta.fai",
+			},
+		},
+		{
+			testing: "make windows from genome selected, but missing fasta index file",
+			bed: Bedfile{
+				MakeWindows: GenomeMW,
+			},
+			shouldFail: true,
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -242,84 +523,954 @@ func TestVerifyFirstBase(t *testing.T) {
 	}
 }
 
-func TestHandleCCSSorting(t *testing.T) {
+func TestVerifyIndex(t *testing.T) {
 	t.Parallel()
 	type testCase struct {
-		testing     string
-		bed         Bedfile
-		expectedBed Bedfile
+		testing    string
+		bed        Bedfile
+		shouldFail bool
 	}
 	testCases := []testCase{
 		{
-			testing: "sortType is ccs, and chrOrder is empty",
+			testing: "no index",
 			bed: Bedfile{
-				Inputs:   []string{"/some/path/test.bed"},
-				SortType: CcsST,
+				Output: "/some/path/output.bed",
+				Index:  NoneIT,
 			},
-			expectedBed: Bedfile{
-				Inputs:      []string{"/some/path/test.bed"},
-				SortType:    CcsST,
-				ChrOrder:    humanChrOrder,
-				chrOrderMap: chrOrderToMap(humanChrOrder),
+		},
+		{
+			testing: "tbi index and compressed output",
+			bed: Bedfile{
+				Output: "/some/path/output.bed.gz",
+				Index:  TbiIT,
 			},
 		},
 		{
-			testing: "sortType is ccs, and is set",
+			testing: "csi index and compressed output",
 			bed: Bedfile{
-				Inputs:   []string{"/some/path/test.bed"},
-				SortType: CcsST,
-				ChrOrder: []string{"4", "3", "2", "1"},
+				Output: "/some/path/output.bed.gz",
+				Index:  CsiIT,
 			},
-			expectedBed: Bedfile{
-				Inputs:   []string{"/some/path/test.bed"},
-				SortType: CcsST,
-				ChrOrder: []string{"4", "3", "2", "1"},
-				chrOrderMap: map[string]int{
-					"4": 1,
-					"3": 2,
-					"2": 3,
-					"1": 4,
-				},
+		},
+		{
+			testing: "tbi index and uncompressed output",
+			bed: Bedfile{
+				Output: "/some/path/output.bed",
+				Index:  TbiIT,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "tbi index and stdout",
+			bed: Bedfile{
+				Index: TbiIT,
 			},
+			shouldFail: true,
 		},
 	}
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.testing, func(t *testing.T) {
 			t.Parallel()
-			tc.bed.handleCCSSorting()
-			if diff := deep.Equal(tc.expectedBed, tc.bed); diff != nil {
-				t.Error("expected VS received bed", diff)
+			err := tc.bed.verifyIndex()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
 			}
 		})
 	}
 }
 
-func TestCleanPaths(t *testing.T) {
+func TestVerifyStream(t *testing.T) {
 	t.Parallel()
 	type testCase struct {
-		testing     string
-		bed         Bedfile
-		expectedBed Bedfile
+		testing    string
+		bed        Bedfile
+		shouldFail bool
 	}
+	padding := 50
 	testCases := []testCase{
 		{
-			testing: "correct input, only input path",
+			testing: "stream",
 			bed: Bedfile{
-				Inputs: []string{"/some/path/test.bed"},
+				Stream: true,
 			},
-			expectedBed: Bedfile{
-				Inputs: []string{"/some/path/test.bed"},
+		},
+		{
+			testing: "deduplicate without stream",
+			bed: Bedfile{
+				Deduplicate: true,
 			},
 		},
 		{
-			testing: "unclean paths",
+			testing: "stream and deduplicate",
 			bed: Bedfile{
-				Inputs:   []string{"/some/../path/test1.bed", "./some/../path/./test2.bed"},
-				Output:   "/some/./output/./path/./output.bed",
-				FastaIdx: "some/../some/./fasta/idx/path/./file.fasta.fai",
+				Stream:      true,
+				Deduplicate: true,
 			},
-			expectedBed: Bedfile{
+			shouldFail: true,
+		},
+		{
+			testing: "stream and intersect",
+			bed: Bedfile{
+				Stream:    true,
+				Intersect: "/some/path/intersect.bed",
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and subtract",
+			bed: Bedfile{
+				Stream:   true,
+				Subtract: "/some/path/subtract.bed",
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and complement",
+			bed: Bedfile{
+				Stream:     true,
+				Complement: true,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and closest",
+			bed: Bedfile{
+				Stream:  true,
+				Closest: "/some/path/closest.bed",
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and window",
+			bed: Bedfile{
+				Stream: true,
+				Window: "/some/path/window.bed",
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and flank",
+			bed: Bedfile{
+				Stream: true,
+				Flank:  BothFT,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and make windows",
+			bed: Bedfile{
+				Stream:      true,
+				MakeWindows: RegionsMW,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and liftover",
+			bed: Bedfile{
+				Stream:   true,
+				Liftover: "/some/liftover.chain",
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and split chr",
+			bed: Bedfile{
+				Stream:   true,
+				SplitChr: true,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and scatter",
+			bed: Bedfile{
+				Stream:  true,
+				Scatter: 2,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and padding",
+			bed: Bedfile{
+				Stream:  true,
+				Padding: 10,
+			},
+		},
+		{
+			testing: "stream and fractional padding",
+			bed: Bedfile{
+				Stream:          true,
+				PaddingFraction: 0.5,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and padding col",
+			bed: Bedfile{
+				Stream:     true,
+				PaddingCol: 3,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and min size",
+			bed: Bedfile{
+				Stream:  true,
+				MinSize: 100,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and shrink",
+			bed: Bedfile{
+				Stream: true,
+				Shrink: 10,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and pad left and right",
+			bed: Bedfile{
+				Stream:   true,
+				PadLeft:  &padding,
+				PadRight: &padding,
+			},
+		},
+		{
+			testing: "stream and pad upstream",
+			bed: Bedfile{
+				Stream:      true,
+				StrandCol:   3,
+				PadUpstream: &padding,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and pad downstream",
+			bed: Bedfile{
+				Stream:        true,
+				StrandCol:     3,
+				PadDownstream: &padding,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "stream and shift",
+			bed: Bedfile{
+				Stream: true,
+				Shift:  10,
+			},
+		},
+		{
+			testing: "stream and shift strand",
+			bed: Bedfile{
+				Stream:      true,
+				StrandCol:   3,
+				Shift:       10,
+				ShiftStrand: true,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyStream()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
+
+func TestVerifyMergeCount(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing    string
+		bed        Bedfile
+		shouldFail bool
+	}
+	testCases := []testCase{
+		{
+			testing: "merge count and coords",
+			bed: Bedfile{
+				MergeCount:  true,
+				MergeCoords: true,
+			},
+		},
+		{
+			testing: "no merge without merge count",
+			bed: Bedfile{
+				NoMerge: true,
+			},
+		},
+		{
+			testing: "merge count and no merge",
+			bed: Bedfile{
+				MergeCount: true,
+				NoMerge:    true,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "merge coords and no merge",
+			bed: Bedfile{
+				MergeCoords: true,
+				NoMerge:     true,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyMergeCount()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
+
+func TestVerifyIntersect(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing    string
+		bed        Bedfile
+		shouldFail bool
+	}
+	testCases := []testCase{
+		{
+			testing: "intersect with fraction",
+			bed: Bedfile{
+				Inputs:            []string{"/some/path/test.bed"},
+				Intersect:         "/some/path/intersect.bed",
+				IntersectFraction: 0.5,
+			},
+		},
+		{
+			testing: "negative fraction",
+			bed: Bedfile{
+				Inputs:            []string{"/some/path/test.bed"},
+				Intersect:         "/some/path/intersect.bed",
+				IntersectFraction: -0.1,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "fraction larger than 1",
+			bed: Bedfile{
+				Inputs:            []string{"/some/path/test.bed"},
+				Intersect:         "/some/path/intersect.bed",
+				IntersectFraction: 1.1,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyIntersect()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
+
+func TestVerifySubtract(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing    string
+		bed        Bedfile
+		shouldFail bool
+	}
+	testCases := []testCase{
+		{
+			testing: "subtract with fraction",
+			bed: Bedfile{
+				Inputs:           []string{"/some/path/test.bed"},
+				Subtract:         "/some/path/subtract.bed",
+				SubtractFraction: 0.5,
+			},
+		},
+		{
+			testing: "fraction larger than 1",
+			bed: Bedfile{
+				Inputs:           []string{"/some/path/test.bed"},
+				Subtract:         "/some/path/subtract.bed",
+				SubtractFraction: 2,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifySubtract()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
+
+func TestVerifyWindow(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing    string
+		bed        Bedfile
+		shouldFail bool
+	}
+	zero := 0
+	negative := -1
+	testCases := []testCase{
+		{
+			testing: "window size",
+			bed: Bedfile{
+				Window:     "/some/path/window.bed",
+				WindowSize: 1000,
+			},
+		},
+		{
+			testing: "window left and right",
+			bed: Bedfile{
+				Window:      "/some/path/window.bed",
+				WindowLeft:  &zero,
+				WindowRight: &zero,
+			},
+		},
+		{
+			testing: "window strand and strand col",
+			bed: Bedfile{
+				Window:       "/some/path/window.bed",
+				WindowStrand: true,
+				StrandCol:    3,
+			},
+		},
+		{
+			testing: "negative window size",
+			bed: Bedfile{
+				Window:     "/some/path/window.bed",
+				WindowSize: -1,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "negative window left",
+			bed: Bedfile{
+				Window:     "/some/path/window.bed",
+				WindowLeft: &negative,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "negative window right",
+			bed: Bedfile{
+				Window:      "/some/path/window.bed",
+				WindowRight: &negative,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "window strand without strand col",
+			bed: Bedfile{
+				Window:       "/some/path/window.bed",
+				WindowStrand: true,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyWindow()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
+
+func TestVerifyStrandPadding(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing    string
+		bed        Bedfile
+		shouldFail bool
+	}
+	padding := 10
+	testCases := []testCase{
+		{
+			testing: "upstream and downstream with strand col",
+			bed: Bedfile{
+				StrandCol:     3,
+				PadUpstream:   &padding,
+				PadDownstream: &padding,
+			},
+		},
+		{
+			testing: "left and right without strand col",
+			bed: Bedfile{
+				PadLeft:  &padding,
+				PadRight: &padding,
+			},
+		},
+		{
+			testing: "upstream without strand col",
+			bed: Bedfile{
+				PadUpstream: &padding,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "downstream without strand col",
+			bed: Bedfile{
+				PadDownstream: &padding,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyStrandPadding()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
+
+func TestVerifyAndHandlePadding(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing     string
+		bed         Bedfile
+		expectedBed Bedfile
+		shouldFail  bool
+	}
+	testCases := []testCase{
+		{
+			testing:     "no padding",
+			bed:         Bedfile{},
+			expectedBed: Bedfile{},
+		},
+		{
+			testing: "padding in bp",
+			bed:     Bedfile{PaddingInput: "-10"},
+			expectedBed: Bedfile{
+				PaddingInput: "-10",
+				Padding:      -10,
+			},
+		},
+		{
+			testing: "fractional padding with minimum and maximum",
+			bed: Bedfile{
+				PaddingInput: "0.1x",
+				PaddingMin:   10,
+				PaddingMax:   100,
+			},
+			expectedBed: Bedfile{
+				PaddingInput:    "0.1x",
+				PaddingFraction: 0.1,
+				PaddingMin:      10,
+				PaddingMax:      100,
+			},
+		},
+		{
+			testing:    "padding is not a number",
+			bed:        Bedfile{PaddingInput: "ten"},
+			shouldFail: true,
+		},
+		{
+			testing:    "padding is a fraction without x",
+			bed:        Bedfile{PaddingInput: "0.1"},
+			shouldFail: true,
+		},
+		{
+			testing:    "negative fraction",
+			bed:        Bedfile{PaddingInput: "-0.1x"},
+			shouldFail: true,
+		},
+		{
+			testing: "maximum with padding col",
+			bed: Bedfile{
+				PaddingCol: 4,
+				PaddingMax: 100,
+			},
+			expectedBed: Bedfile{
+				PaddingCol: 4,
+				PaddingMax: 100,
+			},
+		},
+		{
+			testing: "maximum without fractional padding",
+			bed: Bedfile{
+				PaddingInput: "10",
+				PaddingMax:   100,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "negative minimum",
+			bed: Bedfile{
+				PaddingInput: "0.1x",
+				PaddingMin:   -10,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "minimum larger than maximum",
+			bed: Bedfile{
+				PaddingInput: "0.1x",
+				PaddingMin:   100,
+				PaddingMax:   10,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyAndHandlePadding()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail {
+				if diff := deep.Equal(tc.bed, tc.expectedBed); diff != nil {
+					t.Error("expected VS received bed", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyMinSize(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing    string
+		bed        Bedfile
+		shouldFail bool
+	}
+	testCases := []testCase{
+		{
+			testing: "minimum size",
+			bed:     Bedfile{MinSize: 100},
+		},
+		{
+			testing:    "negative minimum size",
+			bed:        Bedfile{MinSize: -100},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyMinSize()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
+
+func TestVerifyShrinkAndShift(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing    string
+		bed        Bedfile
+		shouldFail bool
+	}
+	testCases := []testCase{
+		{
+			testing: "shrink and negative shift",
+			bed: Bedfile{
+				Shrink: 10,
+				Shift:  -10,
+			},
+		},
+		{
+			testing: "shift according to strand",
+			bed: Bedfile{
+				Shift:       10,
+				ShiftStrand: true,
+				StrandCol:   3,
+			},
+		},
+		{
+			testing:    "negative shrink",
+			bed:        Bedfile{Shrink: -10},
+			shouldFail: true,
+		},
+		{
+			testing: "shift according to strand without strand col",
+			bed: Bedfile{
+				Shift:       10,
+				ShiftStrand: true,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "shift and flank",
+			bed: Bedfile{
+				Shift:   10,
+				Padding: 10,
+				Flank:   BothFT,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyShrinkAndShift()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
+
+func TestVerifyOriginalCoords(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing    string
+		bed        Bedfile
+		shouldFail bool
+	}
+	testCases := []testCase{
+		{
+			testing: "original coords when merging",
+			bed:     Bedfile{OriginalCoords: true},
+		},
+		{
+			testing: "original coords when padding",
+			bed: Bedfile{
+				OriginalCoords: true,
+				NoMerge:        true,
+				Padding:        10,
+			},
+		},
+		{
+			testing: "original coords without padding or merging",
+			bed: Bedfile{
+				OriginalCoords: true,
+				NoMerge:        true,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "original coords and flank",
+			bed: Bedfile{
+				OriginalCoords: true,
+				Padding:        10,
+				Flank:          BothFT,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "original coords and merge coords",
+			bed: Bedfile{
+				OriginalCoords: true,
+				MergeCoords:    true,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyOriginalCoords()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
+
+func TestVerifyClippedReport(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing    string
+		bed        Bedfile
+		shouldFail bool
+	}
+	testCases := []testCase{
+		{
+			testing: "clipped report with padding",
+			bed: Bedfile{
+				ClippedReport: "/some/path/clipped.tsv",
+				Padding:       10,
+			},
+		},
+		{
+			testing:    "clipped report without padding",
+			bed:        Bedfile{ClippedReport: "/some/path/clipped.tsv"},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyClippedReport()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
+
+func TestVerifyFlank(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing    string
+		bed        Bedfile
+		shouldFail bool
+	}
+	padding := 10
+	testCases := []testCase{
+		{
+			testing: "no flank",
+			bed:     Bedfile{Flank: NoneFT},
+		},
+		{
+			testing: "both flanks with padding",
+			bed: Bedfile{
+				Flank:   BothFT,
+				Padding: 10,
+			},
+		},
+		{
+			testing: "left flank with left padding",
+			bed: Bedfile{
+				Flank:   LeftFT,
+				PadLeft: &padding,
+			},
+		},
+		{
+			testing: "upstream flank with strand col",
+			bed: Bedfile{
+				Flank:     UpstreamFT,
+				Padding:   10,
+				StrandCol: 3,
+			},
+		},
+		{
+			testing:    "flank without padding",
+			bed:        Bedfile{Flank: BothFT},
+			shouldFail: true,
+		},
+		{
+			testing: "downstream flank without strand col",
+			bed: Bedfile{
+				Flank:   DownstreamFT,
+				Padding: 10,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyFlank()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
+
+func TestHandleCCSSorting(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing     string
+		bed         Bedfile
+		expectedBed Bedfile
+	}
+	testCases := []testCase{
+		{
+			testing: "sortType is ccs, and chrOrder is empty",
+			bed: Bedfile{
+				Inputs:   []string{"/some/path/test.bed"},
+				SortType: CcsST,
+			},
+			expectedBed: Bedfile{
+				Inputs:      []string{"/some/path/test.bed"},
+				SortType:    CcsST,
+				ChrOrder:    humanChrOrder,
+				chrOrderMap: chrOrderToMap(humanChrOrder),
+			},
+		},
+		{
+			testing: "sortType is ccs, and is set",
+			bed: Bedfile{
+				Inputs:   []string{"/some/path/test.bed"},
+				SortType: CcsST,
+				ChrOrder: []string{"4", "3", "2", "1"},
+			},
+			expectedBed: Bedfile{
+				Inputs:   []string{"/some/path/test.bed"},
+				SortType: CcsST,
+				ChrOrder: []string{"4", "3", "2", "1"},
+				chrOrderMap: map[string]int{
+					"4": 1,
+					"3": 2,
+					"2": 3,
+					"1": 4,
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			tc.bed.handleCCSSorting()
+			if diff := deep.Equal(tc.expectedBed, tc.bed); diff != nil {
+				t.Error("expected VS received bed", diff)
+			}
+		})
+	}
+}
+
+func TestCleanPaths(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing     string
+		bed         Bedfile
+		expectedBed Bedfile
+	}
+	testCases := []testCase{
+		{
+			testing: "correct input, only input path",
+			bed: Bedfile{
+				Inputs: []string{"/some/path/test.bed"},
+			},
+			expectedBed: Bedfile{
+				Inputs: []string{"/some/path/test.bed"},
+			},
+		},
+		{
+			testing: "unclean paths",
+			bed: Bedfile{
+				Inputs:   []string{"/some/../path/test1.bed", "./some/../path/./test2.bed"},
+				Output:   "/some/./output/./path/./output.bed",
+				FastaIdx: "some/../some/./fasta/idx/path/./file.fasta.fai",
+			},
+			expectedBed: Bedfile{
 				Inputs:   []string{"/path/test1.bed", "path/test2.bed"},
 				Output:   "/some/output/path/output.bed",
 				FastaIdx: "some/fasta/idx/path/file.fasta.fai",
@@ -414,3 +1565,341 @@ func deepCopyLine(line Line) Line {
 	}
 	return copiedLine
 }
+
+func TestVerifyMakeWindows(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing    string
+		bed        Bedfile
+		shouldFail bool
+	}
+	testCases := []testCase{
+		{
+			testing: "no make windows",
+			bed:     Bedfile{MakeWindows: NoneMW},
+		},
+		{
+			testing: "make windows with size",
+			bed: Bedfile{
+				MakeWindows:     RegionsMW,
+				MakeWindowsSize: 100,
+			},
+		},
+		{
+			testing: "make windows with size and step",
+			bed: Bedfile{
+				MakeWindows:     GenomeMW,
+				MakeWindowsSize: 100,
+				MakeWindowsStep: 50,
+			},
+		},
+		{
+			testing: "make windows with number",
+			bed: Bedfile{
+				MakeWindows:       RegionsMW,
+				MakeWindowsNumber: 3,
+			},
+		},
+		{
+			testing:    "make windows without size or number",
+			bed:        Bedfile{MakeWindows: RegionsMW},
+			shouldFail: true,
+		},
+		{
+			testing: "make windows with both size and number",
+			bed: Bedfile{
+				MakeWindows:       RegionsMW,
+				MakeWindowsSize:   100,
+				MakeWindowsNumber: 3,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "make windows with step and number",
+			bed: Bedfile{
+				MakeWindows:       RegionsMW,
+				MakeWindowsStep:   50,
+				MakeWindowsNumber: 3,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "make windows with negative size",
+			bed: Bedfile{
+				MakeWindows:     RegionsMW,
+				MakeWindowsSize: -100,
+			},
+			shouldFail: true,
+		},
+		{
+			testing:    "size without make windows",
+			bed:        Bedfile{MakeWindowsSize: 100},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyMakeWindows()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
+
+func TestVerifyScatter(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing    string
+		bed        Bedfile
+		shouldFail bool
+	}
+	testCases := []testCase{
+		{
+			testing: "no scatter",
+			bed:     Bedfile{},
+		},
+		{
+			testing: "scatter with directory",
+			bed: Bedfile{
+				Scatter:    3,
+				ScatterDir: "/some/scatter/dir",
+			},
+		},
+		{
+			testing: "scatter with split",
+			bed: Bedfile{
+				Scatter:      3,
+				ScatterDir:   "/some/scatter/dir",
+				ScatterSplit: true,
+			},
+		},
+		{
+			testing:    "scatter without directory",
+			bed:        Bedfile{Scatter: 3},
+			shouldFail: true,
+		},
+		{
+			testing: "negative scatter",
+			bed: Bedfile{
+				Scatter:    -3,
+				ScatterDir: "/some/scatter/dir",
+			},
+			shouldFail: true,
+		},
+		{
+			testing:    "directory without scatter",
+			bed:        Bedfile{ScatterDir: "/some/scatter/dir"},
+			shouldFail: true,
+		},
+		{
+			testing: "scatter with output",
+			bed: Bedfile{
+				Scatter:    3,
+				ScatterDir: "/some/scatter/dir",
+				Output:     "/some/output.bed",
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "scatter with split and keep chr",
+			bed: Bedfile{
+				Scatter:        3,
+				ScatterDir:     "/some/scatter/dir",
+				ScatterSplit:   true,
+				ScatterKeepChr: true,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyScatter()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
+
+func TestVerifySplitChr(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing    string
+		bed        Bedfile
+		shouldFail bool
+	}
+	testCases := []testCase{
+		{
+			testing: "no split chr",
+			bed:     Bedfile{},
+		},
+		{
+			testing: "split chr with output",
+			bed: Bedfile{
+				SplitChr: true,
+				Output:   "/some/output.bed",
+			},
+		},
+		{
+			testing: "split chr with template",
+			bed: Bedfile{
+				SplitChr:         true,
+				Output:           "/some/output.bed",
+				SplitChrTemplate: "/some/chr{chr}.bed",
+			},
+		},
+		{
+			testing: "split chr with index",
+			bed: Bedfile{
+				SplitChr: true,
+				Output:   "/some/output.bed.gz",
+				Index:    TbiIT,
+			},
+		},
+		{
+			testing:    "split chr without output",
+			bed:        Bedfile{SplitChr: true},
+			shouldFail: true,
+		},
+		{
+			testing: "template without split chr",
+			bed: Bedfile{
+				Output:           "/some/output.bed",
+				SplitChrTemplate: "/some/chr{chr}.bed",
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "template without chr",
+			bed: Bedfile{
+				SplitChr:         true,
+				Output:           "/some/output.bed",
+				SplitChrTemplate: "/some/output.bed",
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "index with template not ending with .gz",
+			bed: Bedfile{
+				SplitChr:         true,
+				Output:           "/some/output.bed.gz",
+				SplitChrTemplate: "{prefix}.{chr}.bed",
+				Index:            TbiIT,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifySplitChr()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
+
+func TestVerifyLiftover(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing    string
+		bed        Bedfile
+		shouldFail bool
+	}
+	testCases := []testCase{
+		{
+			testing: "no liftover",
+			bed:     Bedfile{LiftoverMinMatch: 0.95},
+		},
+		{
+			testing: "liftover with unmapped file",
+			bed: Bedfile{
+				Liftover:         "/some/liftover.chain",
+				LiftoverUnmapped: "/some/unmapped.bed",
+				LiftoverMinMatch: 0.95,
+			},
+		},
+		{
+			testing: "unmapped file without liftover",
+			bed: Bedfile{
+				LiftoverUnmapped: "/some/unmapped.bed",
+			},
+			shouldFail: true,
+		},
+		{
+			testing:    "no split without liftover",
+			bed:        Bedfile{LiftoverNoSplit: true},
+			shouldFail: true,
+		},
+		{
+			testing: "min match larger than 1",
+			bed: Bedfile{
+				Liftover:         "/some/liftover.chain",
+				LiftoverMinMatch: 1.5,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "negative min match",
+			bed: Bedfile{
+				Liftover:         "/some/liftover.chain",
+				LiftoverMinMatch: -0.5,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyLiftover()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
+
+func TestVerifyChrAlias(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing    string
+		bed        Bedfile
+		shouldFail bool
+	}
+	testCases := []testCase{
+		{
+			testing: "no alias file",
+			bed:     Bedfile{},
+		},
+		{
+			testing: "alias file with naming convention",
+			bed: Bedfile{
+				ChrAlias:     "/some/chromAlias.txt",
+				ChrAliasName: "ucsc",
+			},
+		},
+		{
+			testing:    "naming convention without alias file",
+			bed:        Bedfile{ChrAliasName: "ucsc"},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.verifyChrAlias()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+		})
+	}
+}
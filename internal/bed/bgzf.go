@@ -0,0 +1,118 @@
+package bed
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+)
+
+// BGZF constants, see the SAM/BAM specification section 4.1
+const (
+	bgzfHeaderSize  = 18
+	bgzfFooterSize  = 8
+	bgzfMaxDataSize = 0xff00 // Max number of uncompressed bytes in a block (same as htslib)
+)
+
+// Empty BGZF block marking the end of the file
+var bgzfEOF = []byte{
+	0x1f, 0x8b, 0x08, 0x04, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0xff, 0x06, 0x00, 0x42, 0x43, 0x02, 0x00,
+	0x1b, 0x00, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00,
+}
+
+// Writer compressing its content into BGZF blocks
+// Note that Close() must be called to flush the last
+// block and write the end of file marker
+type bgzfWriter struct {
+	writer       io.Writer
+	buffer       []byte
+	blockAddress int64 // Offset of the current block in the compressed file
+}
+
+func newBgzfWriter(writer io.Writer) *bgzfWriter {
+	return &bgzfWriter{
+		writer: writer,
+		buffer: make([]byte, 0, bgzfMaxDataSize),
+	}
+}
+
+// Buffer content and write a block each time the buffer is full
+func (bw *bgzfWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), bgzfMaxDataSize-len(bw.buffer))
+		bw.buffer = append(bw.buffer, p[:n]...)
+		p = p[n:]
+		written += n
+		if len(bw.buffer) == bgzfMaxDataSize {
+			if err := bw.flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Compress the buffer into a single BGZF block
+func (bw *bgzfWriter) flush() error {
+	if len(bw.buffer) == 0 {
+		return nil
+	}
+	var compressed bytes.Buffer
+	fw, err := flate.NewWriter(&compressed, flate.DefaultCompression)
+	if err != nil {
+		return err
+	}
+	if _, err := fw.Write(bw.buffer); err != nil {
+		return err
+	}
+	if err := fw.Close(); err != nil {
+		return err
+	}
+	blockSize := bgzfHeaderSize + compressed.Len() + bgzfFooterSize
+
+	block := make([]byte, 0, blockSize)
+	block = append(block, bgzfEOF[:16]...)
+	block = binary.LittleEndian.AppendUint16(block, uint16(blockSize-1))
+	block = append(block, compressed.Bytes()...)
+	block = binary.LittleEndian.AppendUint32(block, crc32.ChecksumIEEE(bw.buffer))
+	block = binary.LittleEndian.AppendUint32(block, uint32(len(bw.buffer)))
+	if _, err := bw.writer.Write(block); err != nil {
+		return err
+	}
+	bw.blockAddress += int64(blockSize)
+	bw.buffer = bw.buffer[:0]
+	return nil
+}
+
+// Virtual offset of the next byte to be written, the block address
+// is stored in the upper 48 bits and the offset within the block in
+// the lower 16 bits
+func (bw *bgzfWriter) virtualOffset() uint64 {
+	return uint64(bw.blockAddress)<<16 | uint64(len(bw.buffer))
+}
+
+// Flush remaining content and write the end of file marker
+func (bw *bgzfWriter) Close() error {
+	if err := bw.flush(); err != nil {
+		return err
+	}
+	_, err := bw.writer.Write(bgzfEOF)
+	return err
+}
+
+// Wrap reader in a gzip reader if the content is gzip compressed.
+// BGZF files are concatenated gzip members and are read the same way
+func decompressIfGzipped(file io.Reader) (io.Reader, error) {
+	reader := bufio.NewReader(file)
+	magic, err := reader.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		return gzip.NewReader(reader)
+	}
+	return reader, nil
+}
@@ -0,0 +1,147 @@
+package bed
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestBgzfWriter(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing        string
+		content        string
+		expectedBlocks int
+	}
+	testCases := []testCase{
+		{
+			testing:        "empty content",
+			content:        "",
+			expectedBlocks: 0,
+		},
+		{
+			testing:        "content fitting in one block",
+			content:        "1\t10\t100\n2\t20\t200\n",
+			expectedBlocks: 1,
+		},
+		{
+			testing:        "content spanning several blocks",
+			content:        strings.Repeat("1\t10\t100\n", 20000),
+			expectedBlocks: 3,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			var compressed bytes.Buffer
+			bw := newBgzfWriter(&compressed)
+			if _, err := bw.Write([]byte(tc.content)); err != nil {
+				t.Fatal(err)
+			}
+			if err := bw.Close(); err != nil {
+				t.Fatal(err)
+			}
+			// Check that the file ends with the EOF marker
+			if !bytes.HasSuffix(compressed.Bytes(), bgzfEOF) {
+				t.Error("compressed content does not end with the BGZF EOF marker")
+			}
+			// Walk through the blocks using the BSIZE field
+			blocks := 0
+			content := compressed.Bytes()[:compressed.Len()-len(bgzfEOF)]
+			for len(content) > 0 {
+				if content[12] != 'B' || content[13] != 'C' {
+					t.Fatalf("missing BC subfield in block %d", blocks)
+				}
+				blockSize := int(binary.LittleEndian.Uint16(content[16:18])) + 1
+				content = content[blockSize:]
+				blocks++
+			}
+			if tc.expectedBlocks != blocks {
+				t.Errorf("expected %d blocks got %d", tc.expectedBlocks, blocks)
+			}
+			// Check that the content can be decompressed
+			gr, err := gzip.NewReader(&compressed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decompressed, err := io.ReadAll(gr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := deep.Equal(tc.content, string(decompressed)); diff != nil {
+				t.Error("expected VS received content", diff)
+			}
+		})
+	}
+}
+
+func TestDecompressIfGzipped(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing  string
+		compress func(string) []byte
+		content  string
+	}
+	gzipCompress := func(content string) []byte {
+		var compressed bytes.Buffer
+		gw := gzip.NewWriter(&compressed)
+		_, _ = gw.Write([]byte(content))
+		_ = gw.Close()
+		return compressed.Bytes()
+	}
+	bgzfCompress := func(content string) []byte {
+		var compressed bytes.Buffer
+		bw := newBgzfWriter(&compressed)
+		_, _ = bw.Write([]byte(content))
+		_ = bw.Close()
+		return compressed.Bytes()
+	}
+	noCompression := func(content string) []byte {
+		return []byte(content)
+	}
+	testCases := []testCase{
+		{
+			testing:  "uncompressed content",
+			compress: noCompression,
+			content:  "1\t10\t100\n2\t20\t200\n",
+		},
+		{
+			testing:  "empty uncompressed content",
+			compress: noCompression,
+			content:  "",
+		},
+		{
+			testing:  "gzip compressed content",
+			compress: gzipCompress,
+			content:  "1\t10\t100\n2\t20\t200\n",
+		},
+		{
+			testing:  "bgzf compressed content",
+			compress: bgzfCompress,
+			content:  strings.Repeat("1\t10\t100\n", 20000),
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			reader, err := decompressIfGzipped(bytes.NewReader(tc.compress(tc.content)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			decompressed, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := deep.Equal(tc.content, string(decompressed)); diff != nil {
+				t.Error("expected VS received content", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package bed
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Chromosome prefix handling types
+var KeepCP = "keep"   // Keep the chromosome names as they are
+var AddCP = "add"     // Add the chr prefix to chromosome names without it
+var StripCP = "strip" // Remove the chr prefix from chromosome names
+
+// Mitochondrial chromosome naming types
+var KeepCM = "keep" // Keep the mitochondrial chromosome name as it is
+var MCM = "M"       // Rename MT (or chrMT) to M (or chrM)
+var MTCM = "MT"     // Rename M (or chrM) to MT (or chrMT)
+
+// Normalise the chromosome name: first the alias table
+// (--chr-alias) is applied, then the chr prefix is added or
+// removed (--chr-prefix), and lastly the mitochondrial
+// chromosome is renamed (--chr-mt)
+func (bf Bedfile) normaliseChr(chr string) string {
+	if name, ok := bf.chrAliasMap[chr]; ok {
+		chr = name
+	}
+	switch bf.ChrPrefix {
+	case AddCP:
+		if !strings.HasPrefix(chr, "chr") {
+			chr = "chr" + chr
+		}
+	case StripCP:
+		chr = strings.TrimPrefix(chr, "chr")
+	}
+	if bf.ChrMT == MCM || bf.ChrMT == MTCM {
+		name, hasPrefix := strings.CutPrefix(chr, "chr")
+		if name == "M" || name == "MT" {
+			chr = bf.ChrMT
+			if hasPrefix {
+				chr = "chr" + chr
+			}
+		}
+	}
+	return chr
+}
+
+// Opening and reading the chromosome alias file if it is set
+func (bf *Bedfile) openAndReadChrAliases() error {
+	if bf.ChrAlias == "" {
+		return nil
+	}
+	file, err := openInput(bf.ChrAlias)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := bf.readChrAliases(file); err != nil {
+		return fmt.Errorf("can't read chromosome alias file %s: %q", bf.ChrAlias, err)
+	}
+	return nil
+}
+
+// Reading the chromosome alias file in UCSC chromAlias format, where
+// each line contains the tab separated names of a chromosome in different
+// naming conventions, and the optional header line starting with # contains
+// the names of the naming conventions. All names are mapped to the name in
+// the naming convention given by --chr-alias-name, or in the first column
+func (bf *Bedfile) readChrAliases(file io.Reader) error {
+	chrAliasMap := map[string]string{}
+	nameIdx := 0
+
+	lineNr := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNr++
+		lineText := scanner.Text()
+
+		// Find the column of the naming convention in the header
+		if strings.HasPrefix(lineText, "#") {
+			if lineNr != 1 {
+				continue
+			}
+			if bf.ChrAliasName != "" {
+				header := strings.Split(strings.TrimSpace(strings.TrimPrefix(lineText, "#")), "\t")
+				nameIdx = slices.Index(header, bf.ChrAliasName)
+				if nameIdx == -1 {
+					return fmt.Errorf("naming convention %s not in header: %s", bf.ChrAliasName, lineText)
+				}
+			}
+			continue
+		}
+		if lineNr == 1 && bf.ChrAliasName != "" {
+			return fmt.Errorf("--chr-alias-name is set, but the first line is not a header: %s", lineText)
+		}
+		if lineText == "" {
+			continue
+		}
+
+		cols := strings.Split(lineText, "\t")
+		if nameIdx > len(cols)-1 || cols[nameIdx] == "" {
+			// The chromosome has no name in the naming convention
+			continue
+		}
+		name := cols[nameIdx]
+		for _, alias := range cols {
+			if alias == "" {
+				continue
+			}
+			if other, ok := chrAliasMap[alias]; ok && other != name {
+				return fmt.Errorf("alias %s on line %d is used for both %s and %s", alias, lineNr, other, name)
+			}
+			chrAliasMap[alias] = name
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if lineNr == 0 {
+		return fmt.Errorf("chromosome alias file %s is empty", bf.ChrAlias)
+	}
+	bf.chrAliasMap = chrAliasMap
+	return nil
+}
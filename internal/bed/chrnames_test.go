@@ -0,0 +1,186 @@
+package bed
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestNormaliseChr(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing     string
+		bed         Bedfile
+		chr         string
+		expectedChr string
+	}
+	testCases := []testCase{
+		{
+			testing:     "keep",
+			bed:         Bedfile{ChrPrefix: KeepCP, ChrMT: KeepCM},
+			chr:         "chr1",
+			expectedChr: "chr1",
+		},
+		{
+			testing:     "add prefix",
+			bed:         Bedfile{ChrPrefix: AddCP},
+			chr:         "1",
+			expectedChr: "chr1",
+		},
+		{
+			testing:     "add prefix to name with prefix",
+			bed:         Bedfile{ChrPrefix: AddCP},
+			chr:         "chr1",
+			expectedChr: "chr1",
+		},
+		{
+			testing:     "strip prefix",
+			bed:         Bedfile{ChrPrefix: StripCP},
+			chr:         "chrX",
+			expectedChr: "X",
+		},
+		{
+			testing:     "M to MT",
+			bed:         Bedfile{ChrMT: MTCM},
+			chr:         "M",
+			expectedChr: "MT",
+		},
+		{
+			testing:     "chrMT to chrM",
+			bed:         Bedfile{ChrMT: MCM},
+			chr:         "chrMT",
+			expectedChr: "chrM",
+		},
+		{
+			testing:     "add prefix and MT to M",
+			bed:         Bedfile{ChrPrefix: AddCP, ChrMT: MCM},
+			chr:         "MT",
+			expectedChr: "chrM",
+		},
+		{
+			testing:     "strip prefix and M to MT",
+			bed:         Bedfile{ChrPrefix: StripCP, ChrMT: MTCM},
+			chr:         "chrM",
+			expectedChr: "MT",
+		},
+		{
+			testing:     "mitochondrial renaming does not affect other chromosomes",
+			bed:         Bedfile{ChrMT: MTCM},
+			chr:         "chrMX",
+			expectedChr: "chrMX",
+		},
+		{
+			testing: "alias before prefix",
+			bed: Bedfile{
+				ChrPrefix:   StripCP,
+				chrAliasMap: map[string]string{"NC_000001.11": "chr1"},
+			},
+			chr:         "NC_000001.11",
+			expectedChr: "1",
+		},
+		{
+			testing: "name not in aliases",
+			bed: Bedfile{
+				chrAliasMap: map[string]string{"NC_000001.11": "chr1"},
+			},
+			chr:         "NC_000002.12",
+			expectedChr: "NC_000002.12",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			chr := tc.bed.normaliseChr(tc.chr)
+			if chr != tc.expectedChr {
+				t.Errorf("expected %q, got %q", tc.expectedChr, chr)
+			}
+		})
+	}
+}
+
+func TestReadChrAliases(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing             string
+		bed                 Bedfile
+		aliasFileContent    string
+		expectedChrAliasMap map[string]string
+		shouldFail          bool
+	}
+	testCases := []testCase{
+		{
+			testing: "header and first column",
+			aliasFileContent: "# ucsc\tensembl\trefseq\n" +
+				"chr1\t1\tNC_000001.11\n" +
+				"chrUn_KI270302v1\t\tNT_187396.1\n",
+			expectedChrAliasMap: map[string]string{
+				"chr1":             "chr1",
+				"1":                "chr1",
+				"NC_000001.11":     "chr1",
+				"chrUn_KI270302v1": "chrUn_KI270302v1",
+				"NT_187396.1":      "chrUn_KI270302v1",
+			},
+		},
+		{
+			testing: "naming convention from header",
+			bed:     Bedfile{ChrAliasName: "ensembl"},
+			aliasFileContent: "# ucsc\tensembl\trefseq\n" +
+				"chr1\t1\tNC_000001.11\n" +
+				"chrUn_KI270302v1\t\tNT_187396.1\n",
+			expectedChrAliasMap: map[string]string{
+				"chr1":         "1",
+				"1":            "1",
+				"NC_000001.11": "1",
+			},
+		},
+		{
+			testing:          "no header",
+			aliasFileContent: "chr1\t1\n",
+			expectedChrAliasMap: map[string]string{
+				"chr1": "chr1",
+				"1":    "chr1",
+			},
+		},
+		{
+			testing: "naming convention not in header",
+			bed:     Bedfile{ChrAliasName: "genbank"},
+			aliasFileContent: "# ucsc\tensembl\trefseq\n" +
+				"chr1\t1\tNC_000001.11\n",
+			shouldFail: true,
+		},
+		{
+			testing:          "naming convention without header",
+			bed:              Bedfile{ChrAliasName: "ensembl"},
+			aliasFileContent: "chr1\t1\tNC_000001.11\n",
+			shouldFail:       true,
+		},
+		{
+			testing: "alias used for two chromosomes",
+			aliasFileContent: "chr1\t1\n" +
+				"chr2\t1\n",
+			shouldFail: true,
+		},
+		{
+			testing:          "empty file",
+			aliasFileContent: "",
+			shouldFail:       true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.readChrAliases(strings.NewReader(tc.aliasFileContent))
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail {
+				if diff := deep.Equal(tc.expectedChrAliasMap, tc.bed.chrAliasMap); diff != nil {
+					t.Error("expected VS received chromosome aliases", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package bed
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Line that was clipped at the chromosome borders when padding,
+// and the number of bases clipped on each side
+type clippedLine struct {
+	original Line
+	padded   Line
+	left     int
+	right    int
+}
+
+// Pad line according to the padding type, and record the
+// line if it was clipped at the chromosome borders
+func (bf Bedfile) padAndRecordClipping(line Line, chrNotInLengthMap []string, clipped []clippedLine) (Line, []string, []clippedLine, error) {
+	padded, chrNotInLengthMap, err := bf.padAccordingToPaddingType(line, chrNotInLengthMap)
+	if err != nil {
+		return Line{}, nil, nil, err
+	}
+	if c, ok := bf.clipping(line, padded); ok {
+		clipped = append(clipped, c)
+	}
+	return padded, chrNotInLengthMap, clipped, nil
+}
+
+// Compare the padded line with the line padded without taking
+// the chromosome borders into account, returns false if the
+// line was not clipped
+func (bf Bedfile) clipping(line, padded Line) (clippedLine, bool) {
+	// Lines on chromosomes not in the fasta index are not padded
+	// with padding type lax
+	if _, ok := bf.chrLengthMap[line.Chr]; !ok && bf.PaddingType == LaxPT {
+		return clippedLine{}, false
+	}
+	moved, left, right, err := bf.paddingOf(line)
+	if err != nil {
+		return clippedLine{}, false
+	}
+	c := clippedLine{
+		original: line,
+		padded:   padded,
+		left:     padded.Start - (moved.Start - left),
+		right:    (moved.Stop + right) - padded.Stop,
+	}
+	return c, c.left > 0 || c.right > 0
+}
+
+// Warn about the lines clipped at the chromosome borders,
+// and write them to the clipped report if selected
+func (bf Bedfile) reportClipping(clipped []clippedLine) error {
+	if len(clipped) > 0 {
+		if bf.ClippedReport == "" {
+			fmt.Fprintf(os.Stderr, "warning: %d regions were clipped at the chromosome borders when padding, use --clipped-report to list them\n", len(clipped))
+		} else {
+			fmt.Fprintf(os.Stderr, "warning: %d regions were clipped at the chromosome borders when padding, see %s\n", len(clipped), bf.ClippedReport)
+		}
+	}
+	if bf.ClippedReport == "" {
+		return nil
+	}
+	file, err := os.Create(bf.ClippedReport)
+	if err != nil {
+		return fmt.Errorf("cannot create clipped report: %v", err)
+	}
+	if err := writeClipped(file, clipped); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Write the clipped lines, with the coordinates before and after
+// padding and the number of bases clipped on each side
+func writeClipped(writer io.Writer, clipped []clippedLine) error {
+	w := bufio.NewWriter(writer)
+	fmt.Fprintln(w, "#chr\tstart\tstop\tpadded_start\tpadded_stop\tclipped_left\tclipped_right")
+	for _, c := range clipped {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\n", c.original.Chr, c.original.Start, c.original.Stop,
+			c.padded.Start, c.padded.Stop, c.left, c.right)
+	}
+	return w.Flush()
+}
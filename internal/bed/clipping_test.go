@@ -0,0 +1,160 @@
+package bed
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestPadAndRecordClipping(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing         string
+		bed             Bedfile
+		lines           []Line
+		expectedClipped []clippedLine
+	}
+	testCases := []testCase{
+		{
+			testing: "clipped at both chromosome borders",
+			bed: Bedfile{
+				PaddingType:  SafePT,
+				Padding:      60,
+				chrLengthMap: testChrLengthMap,
+			},
+			lines: []Line{
+				{
+					Chr: "1", Start: 50, Stop: 51,
+					Full: []string{"1", "50", "51"},
+				},
+				{
+					Chr: "2", Start: 100, Stop: 110,
+					Full: []string{"2", "100", "110"},
+				},
+			},
+			expectedClipped: []clippedLine{
+				{
+					original: Line{
+						Chr: "1", Start: 50, Stop: 51,
+						Full: []string{"1", "50", "51"},
+					},
+					padded: Line{
+						Chr: "1", Start: 0, Stop: 100,
+						Full: []string{"1", "0", "100"},
+					},
+					left:  10,
+					right: 11,
+				},
+			},
+		},
+		{
+			testing: "clipped at first base 1 with asymmetric padding",
+			bed: Bedfile{
+				PaddingType:  SafePT,
+				PadLeft:      &testPadRight,
+				FirstBase:    1,
+				chrLengthMap: testChrLengthMap,
+			},
+			lines: []Line{
+				{
+					Chr: "1", Start: 3, Stop: 10,
+					Full: []string{"1", "3", "10"},
+				},
+			},
+			expectedClipped: []clippedLine{
+				{
+					original: Line{
+						Chr: "1", Start: 3, Stop: 10,
+						Full: []string{"1", "3", "10"},
+					},
+					padded: Line{
+						Chr: "1", Start: 1, Stop: 10,
+						Full: []string{"1", "1", "10"},
+					},
+					left: 3,
+				},
+			},
+		},
+		{
+			testing: "chromosome not in fasta index with padding type lax",
+			bed: Bedfile{
+				PaddingType:  LaxPT,
+				Padding:      60,
+				chrLengthMap: testChrLengthMap,
+			},
+			lines: []Line{
+				{
+					Chr: "X", Start: 50, Stop: 51,
+					Full: []string{"X", "50", "51"},
+				},
+			},
+		},
+		{
+			testing: "chromosome not in fasta index with padding type force",
+			bed: Bedfile{
+				PaddingType:  ForcePT,
+				Padding:      60,
+				chrLengthMap: testChrLengthMap,
+			},
+			lines: []Line{
+				{
+					Chr: "X", Start: 50, Stop: 51,
+					Full: []string{"X", "50", "51"},
+				},
+			},
+			expectedClipped: []clippedLine{
+				{
+					original: Line{
+						Chr: "X", Start: 50, Stop: 51,
+						Full: []string{"X", "50", "51"},
+					},
+					padded: Line{
+						Chr: "X", Start: 0, Stop: 111,
+						Full: []string{"X", "0", "111"},
+					},
+					left: 10,
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			var chrNotInLengthMap []string
+			var clipped []clippedLine
+			var err error
+			for _, l := range tc.lines {
+				_, chrNotInLengthMap, clipped, err = tc.bed.padAndRecordClipping(l, chrNotInLengthMap, clipped)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if diff := deep.Equal(tc.expectedClipped, clipped); diff != nil {
+				t.Error("expected VS received clipped lines", diff)
+			}
+		})
+	}
+}
+
+func TestWriteClipped(t *testing.T) {
+	t.Parallel()
+	clipped := []clippedLine{
+		{
+			original: Line{Chr: "1", Start: 50, Stop: 51},
+			padded:   Line{Chr: "1", Start: 0, Stop: 100},
+			left:     10,
+			right:    11,
+		},
+	}
+	expected := "#chr\tstart\tstop\tpadded_start\tpadded_stop\tclipped_left\tclipped_right\n" +
+		"1\t50\t51\t0\t100\t10\t11\n"
+	var buf bytes.Buffer
+	if err := writeClipped(&buf, clipped); err != nil {
+		t.Fatal(err)
+	}
+	if expected != buf.String() {
+		t.Errorf("expected %q got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,140 @@
+package bed
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Closest ties
+var AllCT = "all"     // Report all closest regions with the same distance
+var FirstCT = "first" // Report the first of the closest regions with the same distance
+var LastCT = "last"   // Report the last of the closest regions with the same distance
+
+// Closest directions
+var BothCD = "both"             // Report the closest region both upstream and downstream
+var UpstreamCD = "upstream"     // Only report the closest region upstream
+var DownstreamCD = "downstream" // Only report the closest region downstream
+
+// Region in the closest file and its distance to a line
+type closestRegion struct {
+	Line
+	distance int
+}
+
+// Append the closest region in the closest file, and the distance
+// to it, to each region
+func (bf *Bedfile) ClosestLines() error {
+	// Check ties and direction, defaults are all and both
+	if bf.ClosestTies != "" && !stringInSlice([]string{AllCT, FirstCT, LastCT}, bf.ClosestTies) {
+		return fmt.Errorf("unknown closest ties %s", bf.ClosestTies)
+	}
+	if bf.ClosestDirection != "" && !stringInSlice([]string{BothCD, UpstreamCD, DownstreamCD}, bf.ClosestDirection) {
+		return fmt.Errorf("unknown closest direction %s", bf.ClosestDirection)
+	}
+	rs, err := bf.readRegionSet(bf.Closest, false, false)
+	if err != nil {
+		return err
+	}
+	// Number of columns used if no closest region is found
+	nrOfCols := 3
+	for _, lines := range rs.index.lines {
+		nrOfCols = len(lines[0].Full)
+		break
+	}
+
+	var closest []Line
+	for _, l := range bf.Lines {
+		regions := bf.closestRegions(rs.index, l)
+		if len(regions) == 0 {
+			closest = append(closest, withDistance(withColumns(l, noClosestRegion(nrOfCols)), -1))
+			continue
+		}
+		switch bf.ClosestTies {
+		case FirstCT:
+			regions = regions[:1]
+		case LastCT:
+			regions = regions[len(regions)-1:]
+		}
+		for _, r := range regions {
+			closest = append(closest, withDistance(withColumns(l, r.Line), r.distance))
+		}
+	}
+	bf.Lines = closest
+	return nil
+}
+
+// The regions in the index closest to the line, ordered by start
+func (bf Bedfile) closestRegions(idx regionIndex, l Line) []closestRegion {
+	var candidates []Line
+	if !bf.ClosestNoOverlap {
+		candidates = bf.overlapping(regionSet{index: idx}, l)
+	}
+	candidates = append(candidates, idx.nextLeft(l.Chr, l.Start-bf.FirstBase)...)
+	candidates = append(candidates, idx.nextRight(l.Chr, l.Stop+bf.FirstBase)...)
+
+	var closest []closestRegion
+	for _, r := range candidates {
+		distance := bf.signedDistance(l, r)
+		if (bf.ClosestDirection == UpstreamCD && distance > 0) ||
+			(bf.ClosestDirection == DownstreamCD && distance < 0) {
+			continue
+		}
+		if len(closest) > 0 {
+			switch cmp.Compare(abs(distance), abs(closest[0].distance)) {
+			case 1:
+				continue
+			case -1:
+				closest = nil
+			}
+		}
+		closest = append(closest, closestRegion{Line: r, distance: distance})
+	}
+	slices.SortStableFunc(closest, func(a, b closestRegion) int {
+		return cmp.Or(cmp.Compare(a.Start, b.Start), cmp.Compare(a.Stop, b.Stop))
+	})
+	return closest
+}
+
+// Distance from the line to the region, 0 if they overlap and 1 if
+// they are touching. The distance is negative if the region is
+// upstream of the line, according to the strand of the line if the
+// strand column is set and otherwise according to the reference
+func (bf Bedfile) signedDistance(l, r Line) int {
+	overlap := bf.overlapLength(l, r)
+	if overlap > 0 {
+		return 0
+	}
+	distance := 1 - overlap
+	upstream := r.Start < l.Start
+	if bf.StrandCol != 0 && isMinusStrand(l.Strand) {
+		upstream = !upstream
+	}
+	if upstream {
+		return -distance
+	}
+	return distance
+}
+
+// Columns used when there is no closest region
+func noClosestRegion(nrOfCols int) Line {
+	full := []string{".", "-1", "-1"}
+	for len(full) < nrOfCols {
+		full = append(full, ".")
+	}
+	return Line{Full: full}
+}
+
+// Copy line with the distance appended
+func withDistance(l Line, distance int) Line {
+	return withColumns(l, Line{Full: []string{strconv.Itoa(distance)}})
+}
+
+// Absolute value of an int
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
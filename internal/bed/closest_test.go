@@ -0,0 +1,216 @@
+package bed
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+var testClosestContent = "1\t5\t8\tx\n" +
+	"1\t20\t25\ty\n" +
+	"1\t22\t26\tz\n" +
+	"1\t45\t47\tw\n"
+
+var testClosestLines = []Line{
+	{
+		Chr: "1", Start: 10, Stop: 20, Strand: "+",
+		Full: []string{"1", "10", "20", "+"},
+	},
+	{
+		Chr: "1", Start: 30, Stop: 40, Strand: "-",
+		Full: []string{"1", "30", "40", "-"},
+	},
+}
+
+func TestClosestLines(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing        string
+		bed            Bedfile
+		closestContent string
+		expectedLines  []Line
+		shouldFail     bool
+	}
+	testCases := []testCase{
+		{
+			testing:        "default",
+			bed:            Bedfile{Lines: testClosestLines},
+			closestContent: testClosestContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 10, Stop: 20, Strand: "+",
+					Full: []string{"1", "10", "20", "+", "1", "20", "25", "y", "1"},
+				},
+				{
+					Chr: "1", Start: 30, Stop: 40, Strand: "-",
+					Full: []string{"1", "30", "40", "-", "1", "22", "26", "z", "-5"},
+				},
+			},
+		},
+		{
+			testing: "strand col set",
+			bed: Bedfile{
+				StrandCol: 4 - 1,
+				Lines:     testClosestLines,
+			},
+			closestContent: testClosestContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 10, Stop: 20, Strand: "+",
+					Full: []string{"1", "10", "20", "+", "1", "20", "25", "y", "1"},
+				},
+				{
+					Chr: "1", Start: 30, Stop: 40, Strand: "-",
+					Full: []string{"1", "30", "40", "-", "1", "22", "26", "z", "5"},
+				},
+			},
+		},
+		{
+			testing: "upstream with strand col set",
+			bed: Bedfile{
+				StrandCol:        4 - 1,
+				ClosestDirection: UpstreamCD,
+				Lines:            testClosestLines,
+			},
+			closestContent: testClosestContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 10, Stop: 20, Strand: "+",
+					Full: []string{"1", "10", "20", "+", "1", "5", "8", "x", "-3"},
+				},
+				{
+					Chr: "1", Start: 30, Stop: 40, Strand: "-",
+					Full: []string{"1", "30", "40", "-", "1", "45", "47", "w", "-6"},
+				},
+			},
+		},
+		{
+			testing: "downstream, no region found",
+			bed: Bedfile{
+				ClosestDirection: DownstreamCD,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 50, Stop: 60,
+						Full: []string{"1", "50", "60", "+"},
+					},
+				},
+			},
+			closestContent: testClosestContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 50, Stop: 60,
+					Full: []string{"1", "50", "60", "+", ".", "-1", "-1", ".", "-1"},
+				},
+			},
+		},
+		{
+			testing: "overlapping regions and ties",
+			bed: Bedfile{
+				Lines: []Line{
+					{
+						Chr: "1", Start: 21, Stop: 23,
+						Full: []string{"1", "21", "23", "+"},
+					},
+				},
+			},
+			closestContent: testClosestContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 21, Stop: 23,
+					Full: []string{"1", "21", "23", "+", "1", "20", "25", "y", "0"},
+				},
+				{
+					Chr: "1", Start: 21, Stop: 23,
+					Full: []string{"1", "21", "23", "+", "1", "22", "26", "z", "0"},
+				},
+			},
+		},
+		{
+			testing: "last tie",
+			bed: Bedfile{
+				ClosestTies: LastCT,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 21, Stop: 23,
+						Full: []string{"1", "21", "23", "+"},
+					},
+				},
+			},
+			closestContent: testClosestContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 21, Stop: 23,
+					Full: []string{"1", "21", "23", "+", "1", "22", "26", "z", "0"},
+				},
+			},
+		},
+		{
+			testing: "no overlap",
+			bed: Bedfile{
+				ClosestNoOverlap: true,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 21, Stop: 23,
+						Full: []string{"1", "21", "23", "+"},
+					},
+				},
+			},
+			closestContent: testClosestContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 21, Stop: 23,
+					Full: []string{"1", "21", "23", "+", "1", "5", "8", "x", "-14"},
+				},
+			},
+		},
+		{
+			testing: "first base 1",
+			bed: Bedfile{
+				FirstBase: 1,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 9, Stop: 10,
+						Full: []string{"1", "9", "10", "+"},
+					},
+				},
+			},
+			closestContent: testClosestContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 9, Stop: 10,
+					Full: []string{"1", "9", "10", "+", "1", "5", "8", "x", "-1"},
+				},
+			},
+		},
+		{
+			testing: "unknown closest ties",
+			bed: Bedfile{
+				ClosestTies: "any",
+				Lines:       testClosestLines,
+			},
+			closestContent: testClosestContent,
+			shouldFail:     true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			tc.bed.Closest = filepath.Join(t.TempDir(), "closest.bed")
+			if err := os.WriteFile(tc.bed.Closest, []byte(tc.closestContent), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			err := tc.bed.ClosestLines()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail {
+				if diff := deep.Equal(tc.expectedLines, tc.bed.Lines); diff != nil {
+					t.Error("expected VS received lines", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package bed
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+)
+
+// Replace the regions with the regions in the genome not covered
+// by them, using the chromosome lengths in the fasta index file
+func (bf *Bedfile) ComplementLines() error {
+	if bf.chrLengthMap == nil {
+		return fmt.Errorf("complement requires a fasta index file")
+	}
+	idx := newRegionIndex(bf.Lines)
+
+	var chrNotInLengthMap []string
+	for chr := range idx.lines {
+		if _, ok := bf.chrLengthMap[chr]; !ok {
+			chrNotInLengthMap = append(chrNotInLengthMap, chr)
+		}
+	}
+	if len(chrNotInLengthMap) > 0 {
+		fmt.Fprintf(os.Stderr, "warning: chromosomes %v not in fasta index file %s, regions on these chromosomes were ignored\n",
+			sortAndDeduplicateListOfStrings(chrNotInLengthMap), bf.FastaIdx)
+	}
+
+	var complement []Line
+	for _, chr := range slices.Sorted(maps.Keys(bf.chrLengthMap)) {
+		length := bf.chrLengthMap[chr]
+		chrLine := Line{
+			Chr: chr, Start: bf.FirstBase, Stop: length,
+			Full: []string{chr, strconv.Itoa(bf.FirstBase), strconv.Itoa(length)},
+		}
+		// The regions in the index are ordered by start
+		complement = append(complement, bf.subtractLine(chrLine, idx.lines[chr])...)
+	}
+	bf.Lines = complement
+	return nil
+}
@@ -0,0 +1,111 @@
+package bed
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestComplementLines(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing       string
+		bed           Bedfile
+		expectedLines []Line
+		shouldFail    bool
+	}
+	testCases := []testCase{
+		{
+			testing: "first base 0",
+			bed: Bedfile{
+				chrLengthMap: map[string]int{"1": 100, "2": 200},
+				Lines: []Line{
+					{
+						Chr: "1", Start: 20, Stop: 30,
+						Full: []string{"1", "20", "30", "A"},
+					},
+					{
+						Chr: "1", Start: 0, Stop: 10,
+						Full: []string{"1", "0", "10", "B"},
+					},
+					{
+						Chr: "1", Start: 25, Stop: 40,
+						Full: []string{"1", "25", "40", "C"},
+					},
+					{
+						Chr: "3", Start: 25, Stop: 40,
+						Full: []string{"3", "25", "40", "D"},
+					},
+				},
+			},
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 10, Stop: 20,
+					Full: []string{"1", "10", "20"},
+				},
+				{
+					Chr: "1", Start: 40, Stop: 100,
+					Full: []string{"1", "40", "100"},
+				},
+				{
+					Chr: "2", Start: 0, Stop: 200,
+					Full: []string{"2", "0", "200"},
+				},
+			},
+		},
+		{
+			testing: "first base 1",
+			bed: Bedfile{
+				FirstBase:    1,
+				chrLengthMap: map[string]int{"1": 100},
+				Lines: []Line{
+					{
+						Chr: "1", Start: 20, Stop: 30,
+						Full: []string{"1", "20", "30", "A"},
+					},
+					{
+						Chr: "1", Start: 1, Stop: 10,
+						Full: []string{"1", "1", "10", "B"},
+					},
+					{
+						Chr: "1", Start: 31, Stop: 100,
+						Full: []string{"1", "31", "100", "C"},
+					},
+				},
+			},
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 11, Stop: 19,
+					Full: []string{"1", "11", "19"},
+				},
+			},
+		},
+		{
+			testing: "no fasta index",
+			bed: Bedfile{
+				Lines: []Line{
+					{
+						Chr: "1", Start: 20, Stop: 30,
+						Full: []string{"1", "20", "30", "A"},
+					},
+				},
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.ComplementLines()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail {
+				if diff := deep.Equal(tc.expectedLines, tc.bed.Lines); diff != nil {
+					t.Error("expected VS received lines", diff)
+				}
+			}
+		})
+	}
+}
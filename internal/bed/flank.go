@@ -0,0 +1,74 @@
+package bed
+
+import "fmt"
+
+// Flank types
+var NoneFT = "none"             // Do not flank
+var BothFT = "both"             // Flanks on both sides of the region
+var LeftFT = "left"             // Flank to the left of the region
+var RightFT = "right"           // Flank to the right of the region
+var UpstreamFT = "upstream"     // Flank upstream of the region according to the strand
+var DownstreamFT = "downstream" // Flank downstream of the region according to the strand
+
+// Replace the regions with their flanks, the size of the
+// flanks is set with the padding options
+func (bf *Bedfile) FlankLines() error {
+	var chrNotInLengthMap []string
+	var clipped []clippedLine
+	var flanks []Line
+
+	// Check flank type
+	if !stringInSlice([]string{BothFT, LeftFT, RightFT, UpstreamFT, DownstreamFT}, bf.Flank) {
+		return fmt.Errorf("unknown flank type %s", bf.Flank)
+	}
+	for _, l := range bf.Lines {
+		var padded Line
+		var err error
+		padded, chrNotInLengthMap, clipped, err = bf.padAndRecordClipping(l, chrNotInLengthMap, clipped)
+		if err != nil {
+			return err
+		}
+		flanks = append(flanks, bf.flanks(l, padded)...)
+	}
+	// Warn depending on padding type
+	bf.paddingWarnings(chrNotInLengthMap)
+	bf.Lines = flanks
+	return bf.reportClipping(clipped)
+}
+
+// Flanks of the line, given the padded line. With first base 1 the
+// regions are closed intervals, so the flanks do not include the
+// first and last base of the line
+func (bf Bedfile) flanks(l, padded Line) []Line {
+	var flanks []Line
+	useLeft, useRight := bf.flankSides(l)
+	left := Line{Start: padded.Start, Stop: l.Start - bf.FirstBase}
+	if useLeft && bf.regionLength(left) > 0 {
+		flanks = append(flanks, withCoordinates(l, left.Start, left.Stop))
+	}
+	right := Line{Start: l.Stop + bf.FirstBase, Stop: padded.Stop}
+	if useRight && bf.regionLength(right) > 0 {
+		flanks = append(flanks, withCoordinates(l, right.Start, right.Stop))
+	}
+	return flanks
+}
+
+// Returns if the left and right flanks are used for the line. For
+// upstream and downstream flanks lines that are not on the - strand
+// are treated as being on the + strand
+func (bf Bedfile) flankSides(l Line) (bool, bool) {
+	switch bf.Flank {
+	case BothFT:
+		return true, true
+	case LeftFT:
+		return true, false
+	case RightFT:
+		return false, true
+	case UpstreamFT:
+		return !isMinusStrand(l.Strand), isMinusStrand(l.Strand)
+	case DownstreamFT:
+		return isMinusStrand(l.Strand), !isMinusStrand(l.Strand)
+	default:
+		return false, false
+	}
+}
@@ -0,0 +1,216 @@
+package bed
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+var testFlankLines = []Line{
+	{
+		Chr: "1", Start: 5, Stop: 20, Strand: "+",
+		Full: []string{"1", "5", "20", "+"},
+	},
+	{
+		Chr: "1", Start: 30, Stop: 40, Strand: "-",
+		Full: []string{"1", "30", "40", "-"},
+	},
+	{
+		Chr: "1", Start: 90, Stop: 100, Strand: ".",
+		Full: []string{"1", "90", "100", "."},
+	},
+}
+
+func TestFlankLines(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing       string
+		bed           Bedfile
+		expectedLines []Line
+		shouldFail    bool
+	}
+	left := 3
+	testCases := []testCase{
+		{
+			testing: "both flanks clipped at chromosome borders",
+			bed: Bedfile{
+				Flank:        BothFT,
+				Padding:      10,
+				PaddingType:  SafePT,
+				chrLengthMap: map[string]int{"1": 100},
+				Lines:        testFlankLines,
+			},
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 0, Stop: 5, Strand: "+",
+					Full: []string{"1", "0", "5", "+"},
+				},
+				{
+					Chr: "1", Start: 20, Stop: 30, Strand: "+",
+					Full: []string{"1", "20", "30", "+"},
+				},
+				{
+					Chr: "1", Start: 20, Stop: 30, Strand: "-",
+					Full: []string{"1", "20", "30", "-"},
+				},
+				{
+					Chr: "1", Start: 40, Stop: 50, Strand: "-",
+					Full: []string{"1", "40", "50", "-"},
+				},
+				{
+					Chr: "1", Start: 80, Stop: 90, Strand: ".",
+					Full: []string{"1", "80", "90", "."},
+				},
+			},
+		},
+		{
+			testing: "left flank with left padding and first base 1",
+			bed: Bedfile{
+				Flank:        LeftFT,
+				Padding:      10,
+				PadLeft:      &left,
+				PaddingType:  SafePT,
+				FirstBase:    1,
+				chrLengthMap: map[string]int{"1": 100},
+				Lines:        testFlankLines,
+			},
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 2, Stop: 4, Strand: "+",
+					Full: []string{"1", "2", "4", "+"},
+				},
+				{
+					Chr: "1", Start: 27, Stop: 29, Strand: "-",
+					Full: []string{"1", "27", "29", "-"},
+				},
+				{
+					Chr: "1", Start: 87, Stop: 89, Strand: ".",
+					Full: []string{"1", "87", "89", "."},
+				},
+			},
+		},
+		{
+			testing: "upstream flank",
+			bed: Bedfile{
+				Flank:       UpstreamFT,
+				Padding:     5,
+				PaddingType: ForcePT,
+				StrandCol:   4,
+				Lines:       testFlankLines,
+			},
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 0, Stop: 5, Strand: "+",
+					Full: []string{"1", "0", "5", "+"},
+				},
+				{
+					Chr: "1", Start: 40, Stop: 45, Strand: "-",
+					Full: []string{"1", "40", "45", "-"},
+				},
+				{
+					Chr: "1", Start: 85, Stop: 90, Strand: ".",
+					Full: []string{"1", "85", "90", "."},
+				},
+			},
+		},
+		{
+			testing: "downstream flank",
+			bed: Bedfile{
+				Flank:       DownstreamFT,
+				Padding:     5,
+				PaddingType: ForcePT,
+				StrandCol:   4,
+				Lines:       testFlankLines,
+			},
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 20, Stop: 25, Strand: "+",
+					Full: []string{"1", "20", "25", "+"},
+				},
+				{
+					Chr: "1", Start: 25, Stop: 30, Strand: "-",
+					Full: []string{"1", "25", "30", "-"},
+				},
+				{
+					Chr: "1", Start: 100, Stop: 105, Strand: ".",
+					Full: []string{"1", "100", "105", "."},
+				},
+			},
+		},
+		{
+			testing: "lax padding type does not flank chromosomes not in fasta index",
+			bed: Bedfile{
+				Flank:        BothFT,
+				Padding:      5,
+				PaddingType:  LaxPT,
+				chrLengthMap: map[string]int{"2": 100},
+				Lines:        testFlankLines,
+			},
+		},
+		{
+			testing: "lax padding type only removes regions on chromosomes not in fasta index",
+			bed: Bedfile{
+				Flank:        BothFT,
+				Padding:      5,
+				PaddingType:  LaxPT,
+				chrLengthMap: map[string]int{"2": 100},
+				Lines: []Line{
+					{
+						Chr: "1", Start: 30, Stop: 40,
+						Full: []string{"1", "30", "40"},
+					},
+					{
+						Chr: "2", Start: 30, Stop: 40,
+						Full: []string{"2", "30", "40"},
+					},
+				},
+			},
+			expectedLines: []Line{
+				{
+					Chr: "2", Start: 25, Stop: 30,
+					Full: []string{"2", "25", "30"},
+				},
+				{
+					Chr: "2", Start: 40, Stop: 45,
+					Full: []string{"2", "40", "45"},
+				},
+			},
+		},
+		{
+			testing: "safe padding type fails on chromosomes not in fasta index",
+			bed: Bedfile{
+				Flank:        BothFT,
+				Padding:      5,
+				PaddingType:  SafePT,
+				chrLengthMap: map[string]int{"2": 100},
+				Lines:        testFlankLines,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "unknown flank type",
+			bed: Bedfile{
+				Flank:       "middle",
+				Padding:     5,
+				PaddingType: ForcePT,
+				Lines:       testFlankLines,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.FlankLines()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail {
+				if diff := deep.Equal(tc.bed.Lines, tc.expectedLines); diff != nil {
+					t.Error("expected VS received lines", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package bed
+
+import "fmt"
+
+// Intersect reports
+var OverlapIR = "overlap"    // The overlapping part of the region
+var OverlapBIR = "overlap-b" // The overlapping part of the region followed by the overlapping region in the intersect file
+var AIR = "a"                // The whole region, once per overlapping region in the intersect file
+var ABIR = "ab"              // The whole region followed by the overlapping region in the intersect file
+var UniqueIR = "unique"      // The whole region, once if it overlaps any region in the intersect file
+
+// Keep the regions overlapping the regions in the intersect file
+func (bf *Bedfile) IntersectLines() error {
+	// Check intersect report, default is overlap
+	if bf.IntersectReport != "" && !stringInSlice([]string{OverlapIR, OverlapBIR, AIR, ABIR, UniqueIR}, bf.IntersectReport) {
+		return fmt.Errorf("unknown intersect report %s", bf.IntersectReport)
+	}
+	rs, err := bf.readRegionSet(bf.Intersect, bf.StrandCol != 0, false)
+	if err != nil {
+		return err
+	}
+
+	var intersected []Line
+	for _, l := range bf.Lines {
+		var overlapping []Line
+		for _, r := range bf.overlapping(rs, l) {
+			if bf.overlapFractionReached(l, r, bf.IntersectFraction) {
+				overlapping = append(overlapping, r)
+			}
+		}
+		if len(overlapping) == 0 {
+			continue
+		}
+		if bf.IntersectReport == UniqueIR {
+			intersected = append(intersected, l)
+			continue
+		}
+		for _, r := range overlapping {
+			intersected = append(intersected, bf.intersectReport(l, r))
+		}
+	}
+	bf.Lines = intersected
+	return nil
+}
+
+// Create the reported line for a region overlapping
+// a region in the intersect file
+func (bf Bedfile) intersectReport(l, r Line) Line {
+	overlap := withCoordinates(l, max(l.Start, r.Start), min(l.Stop, r.Stop))
+	switch bf.IntersectReport {
+	case AIR:
+		return l
+	case ABIR:
+		return withColumns(l, r)
+	case OverlapBIR:
+		return withColumns(overlap, r)
+	default:
+		return overlap
+	}
+}
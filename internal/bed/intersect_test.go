@@ -0,0 +1,220 @@
+package bed
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+var testIntersectContent = "1\t3\t6\tx\n" +
+	"1\t25\t40\ty\n" +
+	"2\t1\t100\tz\n"
+
+var testIntersectLines = []Line{
+	{
+		Chr: "1", Start: 1, Stop: 4,
+		Full: []string{"1", "1", "4", "A"},
+	},
+	{
+		Chr: "1", Start: 5, Stop: 8,
+		Full: []string{"1", "5", "8", "B"},
+	},
+	{
+		Chr: "1", Start: 6, Stop: 8,
+		Full: []string{"1", "6", "8", "C"},
+	},
+	{
+		Chr: "1", Start: 20, Stop: 30,
+		Full: []string{"1", "20", "30", "D"},
+	},
+	{
+		Chr: "3", Start: 5, Stop: 8,
+		Full: []string{"3", "5", "8", "E"},
+	},
+}
+
+func TestIntersectLines(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing          string
+		bed              Bedfile
+		intersectContent string
+		expectedLines    []Line
+		shouldFail       bool
+	}
+	testCases := []testCase{
+		{
+			testing:          "default report",
+			bed:              Bedfile{Lines: testIntersectLines},
+			intersectContent: testIntersectContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 3, Stop: 4,
+					Full: []string{"1", "3", "4", "A"},
+				},
+				{
+					Chr: "1", Start: 5, Stop: 6,
+					Full: []string{"1", "5", "6", "B"},
+				},
+				{
+					Chr: "1", Start: 25, Stop: 30,
+					Full: []string{"1", "25", "30", "D"},
+				},
+			},
+		},
+		{
+			testing: "overlap-b report",
+			bed: Bedfile{
+				IntersectReport: OverlapBIR,
+				Lines:           testIntersectLines,
+			},
+			intersectContent: testIntersectContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 3, Stop: 4,
+					Full: []string{"1", "3", "4", "A", "1", "3", "6", "x"},
+				},
+				{
+					Chr: "1", Start: 5, Stop: 6,
+					Full: []string{"1", "5", "6", "B", "1", "3", "6", "x"},
+				},
+				{
+					Chr: "1", Start: 25, Stop: 30,
+					Full: []string{"1", "25", "30", "D", "1", "25", "40", "y"},
+				},
+			},
+		},
+		{
+			testing: "a report with first base 1",
+			bed: Bedfile{
+				IntersectReport: AIR,
+				FirstBase:       1,
+				Lines:           testIntersectLines,
+			},
+			intersectContent: testIntersectContent,
+			expectedLines: []Line{
+				testIntersectLines[0],
+				testIntersectLines[1],
+				testIntersectLines[2],
+				testIntersectLines[3],
+			},
+		},
+		{
+			testing: "ab report with several overlaps",
+			bed: Bedfile{
+				IntersectReport: ABIR,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 30,
+						Full: []string{"1", "1", "30", "A"},
+					},
+				},
+			},
+			intersectContent: testIntersectContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 1, Stop: 30,
+					Full: []string{"1", "1", "30", "A", "1", "3", "6", "x"},
+				},
+				{
+					Chr: "1", Start: 1, Stop: 30,
+					Full: []string{"1", "1", "30", "A", "1", "25", "40", "y"},
+				},
+			},
+		},
+		{
+			testing: "unique report with several overlaps",
+			bed: Bedfile{
+				IntersectReport: UniqueIR,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 30,
+						Full: []string{"1", "1", "30", "A"},
+					},
+				},
+			},
+			intersectContent: testIntersectContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 1, Stop: 30,
+					Full: []string{"1", "1", "30", "A"},
+				},
+			},
+		},
+		{
+			testing: "intersect fraction",
+			bed: Bedfile{
+				IntersectReport:   AIR,
+				IntersectFraction: 0.5,
+				Lines:             testIntersectLines,
+			},
+			intersectContent: testIntersectContent,
+			expectedLines: []Line{
+				testIntersectLines[3],
+			},
+		},
+		{
+			testing: "strand col set",
+			bed: Bedfile{
+				IntersectReport: AIR,
+				StrandCol:       4 - 1,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 4, Strand: "+",
+						Full: []string{"1", "1", "4", "+"},
+					},
+					{
+						Chr: "1", Start: 1, Stop: 4, Strand: "-",
+						Full: []string{"1", "1", "4", "-"},
+					},
+				},
+			},
+			intersectContent: "1\t3\t6\t-\n",
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 1, Stop: 4, Strand: "-",
+					Full: []string{"1", "1", "4", "-"},
+				},
+			},
+		},
+		{
+			testing: "unknown intersect report",
+			bed: Bedfile{
+				IntersectReport: "b",
+				Lines:           testIntersectLines,
+			},
+			intersectContent: testIntersectContent,
+			shouldFail:       true,
+		},
+		{
+			testing: "strand col outside intersect file",
+			bed: Bedfile{
+				StrandCol: 4 - 1,
+				Lines:     testIntersectLines,
+			},
+			intersectContent: "1\t3\t6\n",
+			shouldFail:       true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			tc.bed.Intersect = filepath.Join(t.TempDir(), "intersect.bed")
+			if err := os.WriteFile(tc.bed.Intersect, []byte(tc.intersectContent), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			err := tc.bed.IntersectLines()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail {
+				if diff := deep.Equal(tc.expectedLines, tc.bed.Lines); diff != nil {
+					t.Error("expected VS received lines", diff)
+				}
+			}
+		})
+	}
+}
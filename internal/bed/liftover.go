@@ -0,0 +1,322 @@
+package bed
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Reasons for regions not being lifted over, as used by UCSC liftOver
+const (
+	deletedReason          = "Deleted in new"
+	partiallyDeletedReason = "Partially deleted in new"
+	splitReason            = "Split in new"
+)
+
+// Ungapped aligned block in a chain, where size bases starting at
+// tStart in the source assembly are aligned to qStart in the target
+type chainBlock struct {
+	tStart int
+	qStart int
+	size   int
+}
+
+// Alignment of a chromosome in the source assembly (t) to a chromosome
+// in the target assembly (q) from a UCSC chain file. Coordinates are
+// zero-based and half-open, and if qReverse is true the q coordinates
+// are on the reverse strand of the target chromosome
+type chain struct {
+	tName    string
+	tStart   int
+	tEnd     int
+	qName    string
+	qSize    int
+	qStart   int
+	qReverse bool
+	blocks   []chainBlock
+}
+
+// Region that could not be lifted over and the reason why
+type unmappedLine struct {
+	line   Line
+	reason string
+}
+
+// Lift the regions over to another assembly using the chain file.
+// Regions are split into one region per chain if they are aligned
+// by more than one chain, unless --liftover-no-split is set
+func (bf *Bedfile) LiftoverLines() error {
+	chains, err := openAndReadChains(bf.Liftover)
+	if err != nil {
+		return err
+	}
+	var lifted []Line
+	var unmapped []unmappedLine
+	for _, l := range bf.Lines {
+		mapped, reason := bf.liftover(chains[l.Chr], l)
+		if reason != "" {
+			unmapped = append(unmapped, unmappedLine{line: l, reason: reason})
+			continue
+		}
+		lifted = append(lifted, mapped...)
+	}
+	bf.Lines = lifted
+	return bf.reportUnmapped(unmapped)
+}
+
+// Lift over a single line using the chains of its chromosome,
+// returns the reason if the line could not be lifted over
+func (bf Bedfile) liftover(chains []chain, l Line) ([]Line, string) {
+	// Use zero-based half-open coordinates while lifting over
+	start, stop := l.Start-bf.FirstBase, l.Stop
+	// Regions without length are lifted over as the base after them
+	searchStop := max(stop, start+1)
+
+	var lifted []Line
+	var mappedBases int
+	for _, c := range chains {
+		if c.tEnd <= start || c.tStart >= searchStop {
+			continue
+		}
+		qStart, qStop, bases := c.mapRange(start, searchStop)
+		if bases == 0 {
+			continue
+		}
+		if stop <= start {
+			if c.qReverse {
+				qStart = qStop
+			} else {
+				qStop = qStart
+			}
+		}
+		mappedBases += bases
+		lifted = append(lifted, bf.liftedLine(l, c, qStart, qStop))
+	}
+	switch {
+	case len(lifted) == 0:
+		return nil, deletedReason
+	case float64(mappedBases) < bf.LiftoverMinMatch*float64(searchStop-start):
+		return nil, partiallyDeletedReason
+	case len(lifted) > 1 && bf.LiftoverNoSplit:
+		return nil, splitReason
+	}
+	return lifted, ""
+}
+
+// Map the range to the target assembly, returns the span of the
+// aligned bases on the forward strand of the target chromosome
+// and the number of aligned bases
+func (c chain) mapRange(start, stop int) (int, int, int) {
+	var qStart, qStop, bases int
+	first := sort.Search(len(c.blocks), func(i int) bool {
+		return c.blocks[i].tStart+c.blocks[i].size > start
+	})
+	for _, b := range c.blocks[first:] {
+		if b.tStart >= stop {
+			break
+		}
+		overlapStart := max(start, b.tStart)
+		overlapStop := min(stop, b.tStart+b.size)
+		if overlapStart >= overlapStop {
+			continue
+		}
+		blockQStart := b.qStart + overlapStart - b.tStart
+		blockQStop := blockQStart + overlapStop - overlapStart
+		if bases == 0 {
+			qStart = blockQStart
+		}
+		qStop = blockQStop
+		bases += overlapStop - overlapStart
+	}
+	if c.qReverse {
+		qStart, qStop = c.qSize-qStop, c.qSize-qStart
+	}
+	return qStart, qStop, bases
+}
+
+// Copy of the line moved to the target chromosome and coordinates,
+// with the strand flipped if the chain is on the reverse strand
+func (bf Bedfile) liftedLine(l Line, c chain, qStart, qStop int) Line {
+	lifted := withCoordinates(l, qStart+bf.FirstBase, qStop)
+	lifted.Chr = c.qName
+	lifted.Full[chrIdx] = c.qName
+	if c.qReverse && bf.StrandCol > stopIdx {
+		lifted.Strand = flipStrand(l.Strand)
+		lifted.Full[bf.StrandCol] = lifted.Strand
+	}
+	return lifted
+}
+
+// Opposite strands, in the same format as the strand
+var oppositeStrands = map[string]string{
+	"+": "-", "-": "+",
+	"+1": "-1", "1": "-1", "-1": "1",
+}
+
+// Returns the opposite strand, unknown strands (.) are kept
+func flipStrand(strand string) string {
+	if opposite, ok := oppositeStrands[strand]; ok {
+		return opposite
+	}
+	return strand
+}
+
+// Open and read the chain file, it is decompressed if it is gzip compressed
+func openAndReadChains(input string) (map[string][]chain, error) {
+	file, err := openInput(input)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	reader, err := decompressIfGzipped(file)
+	if err != nil {
+		return nil, fmt.Errorf("can't decompress chain file %s: %q", input, err)
+	}
+	chains, err := readChains(reader)
+	if err != nil {
+		return nil, fmt.Errorf("can't read chain file %s: %q", input, err)
+	}
+	return chains, nil
+}
+
+// Reading the chains in UCSC chain format, the chains of
+// each chromosome are sorted by their start
+func readChains(file io.Reader) (map[string][]chain, error) {
+	chains := map[string][]chain{}
+	var current *chain
+	var t, q int
+
+	lineNr := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNr++
+		cols := strings.Fields(scanner.Text())
+		if len(cols) == 0 || strings.HasPrefix(cols[0], "#") {
+			continue
+		}
+
+		// Start of a new chain:
+		// chain score tName tSize tStrand tStart tEnd qName qSize qStrand qStart qEnd id
+		if cols[0] == "chain" {
+			if current != nil {
+				return nil, fmt.Errorf("chain on line %d starts before the previous chain ended", lineNr)
+			}
+			c, err := parseChainHeader(cols)
+			if err != nil {
+				return nil, fmt.Errorf("%w on line %d", err, lineNr)
+			}
+			current = &c
+			t, q = c.tStart, c.qStart
+			continue
+		}
+
+		// Alignment data: size [dt dq], where the last block
+		// of the chain has only the size
+		if current == nil {
+			return nil, fmt.Errorf("alignment data outside of a chain on line %d", lineNr)
+		}
+		if len(cols) != 1 && len(cols) != 3 {
+			return nil, fmt.Errorf("expected 1 or 3 columns of alignment data on line %d got %d", lineNr, len(cols))
+		}
+		var values []int
+		for _, col := range cols {
+			value, err := strconv.Atoi(col)
+			if err != nil {
+				return nil, fmt.Errorf("non-int alignment data on line %d: %s", lineNr, col)
+			}
+			values = append(values, value)
+		}
+		current.blocks = append(current.blocks, chainBlock{tStart: t, qStart: q, size: values[0]})
+		if len(values) == 1 {
+			chains[current.tName] = append(chains[current.tName], *current)
+			current = nil
+			continue
+		}
+		t += values[0] + values[1]
+		q += values[0] + values[2]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if current != nil {
+		return nil, fmt.Errorf("the last chain is not ended")
+	}
+	if lineNr == 0 {
+		return nil, fmt.Errorf("chain file is empty")
+	}
+	for _, cs := range chains {
+		slices.SortFunc(cs, func(a, b chain) int {
+			return a.tStart - b.tStart
+		})
+	}
+	return chains, nil
+}
+
+// Parse the header line of a chain
+func parseChainHeader(cols []string) (chain, error) {
+	if len(cols) < 12 {
+		return chain{}, fmt.Errorf("expected at least 12 columns in chain header got %d", len(cols))
+	}
+	ints := map[int]int{}
+	for _, i := range []int{5, 6, 8, 10} {
+		value, err := strconv.Atoi(cols[i])
+		if err != nil {
+			return chain{}, fmt.Errorf("non-int value in chain header: %s", cols[i])
+		}
+		ints[i] = value
+	}
+	if cols[4] != "+" {
+		return chain{}, fmt.Errorf("expected the source strand in chain header to be + got %s", cols[4])
+	}
+	if cols[9] != "+" && cols[9] != "-" {
+		return chain{}, fmt.Errorf("unexpected target strand in chain header: %s", cols[9])
+	}
+	return chain{
+		tName:    cols[2],
+		tStart:   ints[5],
+		tEnd:     ints[6],
+		qName:    cols[7],
+		qSize:    ints[8],
+		qStart:   ints[10],
+		qReverse: cols[9] == "-",
+	}, nil
+}
+
+// Warn about the regions that could not be lifted over,
+// and write them to --liftover-unmapped if it is set
+func (bf Bedfile) reportUnmapped(unmapped []unmappedLine) error {
+	if len(unmapped) > 0 {
+		if bf.LiftoverUnmapped == "" {
+			fmt.Fprintf(os.Stderr, "warning: %d regions could not be lifted over, use --liftover-unmapped to list them\n", len(unmapped))
+		} else {
+			fmt.Fprintf(os.Stderr, "warning: %d regions could not be lifted over, see %s\n", len(unmapped), bf.LiftoverUnmapped)
+		}
+	}
+	if bf.LiftoverUnmapped == "" {
+		return nil
+	}
+	file, err := os.Create(bf.LiftoverUnmapped)
+	if err != nil {
+		return fmt.Errorf("cannot create unmapped file: %v", err)
+	}
+	if err := writeUnmapped(file, unmapped); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Write the unmapped lines, each preceded by a comment line with the
+// reason it could not be lifted over (as done by UCSC liftOver)
+func writeUnmapped(writer io.Writer, unmapped []unmappedLine) error {
+	w := bufio.NewWriter(writer)
+	for _, u := range unmapped {
+		fmt.Fprintf(w, "#%s\n%s\n", u.reason, strings.Join(u.line.Full, "\t"))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,326 @@
+package bed
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+// Chains used in the liftover tests:
+// 1:0-1000 to chr1:100-1090 with a 10 bp gap in chr1 at 500,
+// 2:0-200 to the reverse strand of chr2:100-300 (chr2:700-900 on the forward strand),
+// 2:200-400 to chr5:0-200
+var testChains = map[string][]chain{
+	"1": {
+		{
+			tName: "1", tStart: 0, tEnd: 1000,
+			qName: "chr1", qSize: 1100, qStart: 100,
+			blocks: []chainBlock{
+				{tStart: 0, qStart: 100, size: 500},
+				{tStart: 510, qStart: 600, size: 490},
+			},
+		},
+	},
+	"2": {
+		{
+			tName: "2", tStart: 0, tEnd: 200,
+			qName: "chr2", qSize: 1000, qStart: 100, qReverse: true,
+			blocks: []chainBlock{
+				{tStart: 0, qStart: 100, size: 200},
+			},
+		},
+		{
+			tName: "2", tStart: 200, tEnd: 400,
+			qName: "chr5", qSize: 800,
+			blocks: []chainBlock{
+				{tStart: 200, qStart: 0, size: 200},
+			},
+		},
+	},
+}
+
+func TestReadChains(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing        string
+		chainFile      string
+		expectedChains map[string][]chain
+		shouldFail     bool
+	}
+	testCases := []testCase{
+		{
+			testing: "correct chain file",
+			chainFile: "chain 800 2 500 + 200 400 chr5 800 + 0 200 3\n" +
+				"200\n" +
+				"\n" +
+				"chain 1000 1 1000 + 0 1000 chr1 1100 + 100 1090 1\n" +
+				"500\t10\t0\n" +
+				"490\n" +
+				"\n" +
+				"chain 900 2 500 + 0 200 chr2 1000 - 100 300 2\n" +
+				"200\n",
+			expectedChains: testChains,
+		},
+		{
+			testing: "comment lines",
+			chainFile: "#some comment\n" +
+				"chain 800 2 500 + 200 400 chr5 800 + 0 200 3\n" +
+				"200\n",
+			expectedChains: map[string][]chain{"2": testChains["2"][1:]},
+		},
+		{
+			testing:    "empty chain file",
+			chainFile:  "",
+			shouldFail: true,
+		},
+		{
+			testing:    "alignment data outside of a chain",
+			chainFile:  "200\n",
+			shouldFail: true,
+		},
+		{
+			testing: "chain not ended",
+			chainFile: "chain 1000 1 1000 + 0 1000 chr1 1100 + 100 1090 1\n" +
+				"500\t10\t0\n",
+			shouldFail: true,
+		},
+		{
+			testing: "too few columns in chain header",
+			chainFile: "chain 1000 1 1000 + 0 1000 chr1 1100 +\n" +
+				"1000\n",
+			shouldFail: true,
+		},
+		{
+			testing: "non-int alignment data",
+			chainFile: "chain 1000 1 1000 + 0 1000 chr1 1100 + 100 1090 1\n" +
+				"500\tA\t0\n" +
+				"490\n",
+			shouldFail: true,
+		},
+		{
+			testing: "wrong number of columns in alignment data",
+			chainFile: "chain 1000 1 1000 + 0 1000 chr1 1100 + 100 1090 1\n" +
+				"500\t10\n" +
+				"490\n",
+			shouldFail: true,
+		},
+		{
+			testing: "unexpected strand",
+			chainFile: "chain 1000 1 1000 + 0 1000 chr1 1100 x 100 1090 1\n" +
+				"1000\n",
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			chains, err := readChains(strings.NewReader(tc.chainFile))
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail {
+				if diff := deep.Equal(tc.expectedChains, chains); diff != nil {
+					t.Error("expected VS received chains", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestLiftover(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing        string
+		bed            Bedfile
+		line           Line
+		expectedLines  []Line
+		expectedReason string
+	}
+	testCases := []testCase{
+		{
+			testing: "forward strand",
+			bed:     Bedfile{LiftoverMinMatch: 0.95},
+			line: Line{
+				Chr: "1", Start: 10, Stop: 20,
+				Full: []string{"1", "10", "20", "A"},
+			},
+			expectedLines: []Line{
+				{
+					Chr: "chr1", Start: 110, Stop: 120,
+					Full: []string{"chr1", "110", "120", "A"},
+				},
+			},
+		},
+		{
+			testing: "reverse strand with strand column",
+			bed: Bedfile{
+				LiftoverMinMatch: 0.95,
+				StrandCol:        3,
+			},
+			line: Line{
+				Chr: "2", Start: 50, Stop: 60, Strand: "+",
+				Full: []string{"2", "50", "60", "+"},
+			},
+			expectedLines: []Line{
+				{
+					Chr: "chr2", Start: 840, Stop: 850, Strand: "-",
+					Full: []string{"chr2", "840", "850", "-"},
+				},
+			},
+		},
+		{
+			testing: "region spanning a gap",
+			bed:     Bedfile{LiftoverMinMatch: 0.5},
+			line: Line{
+				Chr: "1", Start: 495, Stop: 520,
+				Full: []string{"1", "495", "520"},
+			},
+			expectedLines: []Line{
+				{
+					Chr: "chr1", Start: 595, Stop: 610,
+					Full: []string{"chr1", "595", "610"},
+				},
+			},
+		},
+		{
+			testing: "region spanning a gap with too few bases lifted over",
+			bed:     Bedfile{LiftoverMinMatch: 0.95},
+			line: Line{
+				Chr: "1", Start: 495, Stop: 520,
+				Full: []string{"1", "495", "520"},
+			},
+			expectedReason: partiallyDeletedReason,
+		},
+		{
+			testing: "split region",
+			bed:     Bedfile{LiftoverMinMatch: 0.95},
+			line: Line{
+				Chr: "2", Start: 150, Stop: 250,
+				Full: []string{"2", "150", "250"},
+			},
+			expectedLines: []Line{
+				{
+					Chr: "chr2", Start: 700, Stop: 750,
+					Full: []string{"chr2", "700", "750"},
+				},
+				{
+					Chr: "chr5", Start: 0, Stop: 50,
+					Full: []string{"chr5", "0", "50"},
+				},
+			},
+		},
+		{
+			testing: "split region with no split",
+			bed: Bedfile{
+				LiftoverMinMatch: 0.95,
+				LiftoverNoSplit:  true,
+			},
+			line: Line{
+				Chr: "2", Start: 150, Stop: 250,
+				Full: []string{"2", "150", "250"},
+			},
+			expectedReason: splitReason,
+		},
+		{
+			testing: "first base 1",
+			bed: Bedfile{
+				LiftoverMinMatch: 0.95,
+				FirstBase:        1,
+			},
+			line: Line{
+				Chr: "2", Start: 151, Stop: 160,
+				Full: []string{"2", "151", "160"},
+			},
+			expectedLines: []Line{
+				{
+					Chr: "chr2", Start: 741, Stop: 750,
+					Full: []string{"chr2", "741", "750"},
+				},
+			},
+		},
+		{
+			testing: "region without length",
+			bed:     Bedfile{LiftoverMinMatch: 0.95},
+			line: Line{
+				Chr: "2", Start: 50, Stop: 50,
+				Full: []string{"2", "50", "50"},
+			},
+			expectedLines: []Line{
+				{
+					Chr: "chr2", Start: 850, Stop: 850,
+					Full: []string{"chr2", "850", "850"},
+				},
+			},
+		},
+		{
+			testing: "region outside of the chains",
+			bed:     Bedfile{LiftoverMinMatch: 0.95},
+			line: Line{
+				Chr: "1", Start: 2000, Stop: 2100,
+				Full: []string{"1", "2000", "2100"},
+			},
+			expectedReason: deletedReason,
+		},
+		{
+			testing: "chromosome without chains",
+			bed:     Bedfile{LiftoverMinMatch: 0.95},
+			line: Line{
+				Chr: "3", Start: 0, Stop: 10,
+				Full: []string{"3", "0", "10"},
+			},
+			expectedReason: deletedReason,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			lines, reason := tc.bed.liftover(testChains[tc.line.Chr], tc.line)
+			if reason != tc.expectedReason {
+				t.Errorf("expected reason %q, got %q", tc.expectedReason, reason)
+			}
+			if diff := deep.Equal(tc.expectedLines, lines); diff != nil {
+				t.Error("expected VS received lines", diff)
+			}
+		})
+	}
+}
+
+func TestFlipStrand(t *testing.T) {
+	t.Parallel()
+	for strand, expected := range map[string]string{
+		"+": "-", "-": "+", "+1": "-1", "1": "-1", "-1": "1", ".": ".",
+	} {
+		if flipped := flipStrand(strand); flipped != expected {
+			t.Errorf("expected %s to be flipped to %s, got %s", strand, expected, flipped)
+		}
+	}
+}
+
+func TestWriteUnmapped(t *testing.T) {
+	t.Parallel()
+	unmapped := []unmappedLine{
+		{
+			line:   Line{Chr: "1", Start: 2000, Stop: 2100, Full: []string{"1", "2000", "2100", "A"}},
+			reason: deletedReason,
+		},
+		{
+			line:   Line{Chr: "2", Start: 150, Stop: 250, Full: []string{"2", "150", "250", "B"}},
+			reason: splitReason,
+		},
+	}
+	expected := "#Deleted in new\n" +
+		"1\t2000\t2100\tA\n" +
+		"#Split in new\n" +
+		"2\t150\t250\tB\n"
+	var buf bytes.Buffer
+	if err := writeUnmapped(&buf, unmapped); err != nil {
+		t.Fatal(err)
+	}
+	if expected != buf.String() {
+		t.Errorf("expected %q got %q", expected, buf.String())
+	}
+}
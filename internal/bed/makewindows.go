@@ -0,0 +1,88 @@
+package bed
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// Make windows types
+var NoneMW = "none"       // Do not make windows
+var RegionsMW = "regions" // Split each region into windows
+var GenomeMW = "genome"   // Split each chromosome in the fasta index file into windows
+
+// Returns true if making windows is selected
+func (bf Bedfile) MakeWindowsSelected() bool {
+	return bf.MakeWindows != "" && bf.MakeWindows != NoneMW
+}
+
+// Replace the regions with windows made from the regions, or from
+// the chromosomes in the fasta index file. The index of the window
+// and the coordinates of the region or chromosome it was made from
+// are appended to each window
+func (bf *Bedfile) MakeWindowsLines() error {
+	var sources []Line
+	switch bf.MakeWindows {
+	case RegionsMW:
+		sources = bf.Lines
+	case GenomeMW:
+		if bf.chrLengthMap == nil {
+			return fmt.Errorf("making windows from the genome requires a fasta index file")
+		}
+		for _, chr := range slices.Sorted(maps.Keys(bf.chrLengthMap)) {
+			length := bf.chrLengthMap[chr]
+			sources = append(sources, Line{
+				Chr: chr, Start: bf.FirstBase, Stop: length,
+				Full: []string{chr, strconv.Itoa(bf.FirstBase), strconv.Itoa(length)},
+			})
+		}
+	default:
+		return fmt.Errorf("unknown make windows type %s", bf.MakeWindows)
+	}
+
+	var windows []Line
+	for _, l := range sources {
+		windows = append(windows, bf.makeWindows(l)...)
+	}
+	bf.Lines = windows
+	return nil
+}
+
+// Split the line into windows of a fixed size, or into a fixed
+// number of windows of equal size
+func (bf Bedfile) makeWindows(l Line) []Line {
+	var windows []Line
+	// Use half-open coordinates while splitting
+	stop := l.Stop + bf.FirstBase
+	addWindow := func(windowStart, windowStop int) {
+		window := withCoordinates(l, windowStart, windowStop-bf.FirstBase)
+		windows = append(windows, withColumns(window, Line{
+			Full: []string{strconv.Itoa(len(windows) + 1), coordinates(l)},
+		}))
+	}
+	if bf.MakeWindowsNumber > 0 {
+		length := stop - l.Start
+		for i := range bf.MakeWindowsNumber {
+			windowStart := l.Start + i*length/bf.MakeWindowsNumber
+			windowStop := l.Start + (i+1)*length/bf.MakeWindowsNumber
+			if windowStart < windowStop {
+				addWindow(windowStart, windowStop)
+			}
+		}
+		return windows
+	}
+	step := bf.MakeWindowsSize
+	if bf.MakeWindowsStep > 0 {
+		step = bf.MakeWindowsStep
+	}
+	for windowStart := l.Start; windowStart < stop; windowStart += step {
+		windowStop := min(windowStart+bf.MakeWindowsSize, stop)
+		addWindow(windowStart, windowStop)
+		// Windows after the one reaching the end would be inside it
+		if windowStop == stop {
+			break
+		}
+	}
+	return windows
+}
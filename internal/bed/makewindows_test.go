@@ -0,0 +1,155 @@
+package bed
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestMakeWindowsLines(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing       string
+		bed           Bedfile
+		expectedLines []Line
+		shouldFail    bool
+	}
+	testCases := []testCase{
+		{
+			testing: "regions with window size",
+			bed: Bedfile{
+				MakeWindows:     RegionsMW,
+				MakeWindowsSize: 30,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 100, Stop: 160,
+						Full: []string{"1", "100", "160", "A"},
+					},
+					{
+						Chr: "1", Start: 200, Stop: 220,
+						Full: []string{"1", "200", "220", "B"},
+					},
+				},
+			},
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 100, Stop: 130,
+					Full: []string{"1", "100", "130", "A", "1", "1:100-160"},
+				},
+				{
+					Chr: "1", Start: 130, Stop: 160,
+					Full: []string{"1", "130", "160", "A", "2", "1:100-160"},
+				},
+				{
+					Chr: "1", Start: 200, Stop: 220,
+					Full: []string{"1", "200", "220", "B", "1", "1:200-220"},
+				},
+			},
+		},
+		{
+			testing: "regions with sliding windows",
+			bed: Bedfile{
+				MakeWindows:     RegionsMW,
+				MakeWindowsSize: 30,
+				MakeWindowsStep: 20,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 100, Stop: 170,
+						Full: []string{"1", "100", "170"},
+					},
+				},
+			},
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 100, Stop: 130,
+					Full: []string{"1", "100", "130", "1", "1:100-170"},
+				},
+				{
+					Chr: "1", Start: 120, Stop: 150,
+					Full: []string{"1", "120", "150", "2", "1:100-170"},
+				},
+				{
+					Chr: "1", Start: 140, Stop: 170,
+					Full: []string{"1", "140", "170", "3", "1:100-170"},
+				},
+			},
+		},
+		{
+			testing: "regions with number of windows and first base 1",
+			bed: Bedfile{
+				FirstBase:         1,
+				MakeWindows:       RegionsMW,
+				MakeWindowsNumber: 2,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 11,
+						Full: []string{"1", "1", "11"},
+					},
+					{
+						Chr: "1", Start: 20, Stop: 20,
+						Full: []string{"1", "20", "20"},
+					},
+				},
+			},
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 1, Stop: 5,
+					Full: []string{"1", "1", "5", "1", "1:1-11"},
+				},
+				{
+					Chr: "1", Start: 6, Stop: 11,
+					Full: []string{"1", "6", "11", "2", "1:1-11"},
+				},
+				{
+					Chr: "1", Start: 20, Stop: 20,
+					Full: []string{"1", "20", "20", "1", "1:20-20"},
+				},
+			},
+		},
+		{
+			testing: "genome with window size",
+			bed: Bedfile{
+				MakeWindows:     GenomeMW,
+				MakeWindowsSize: 60,
+				chrLengthMap:    map[string]int{"2": 50, "1": 100},
+			},
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 0, Stop: 60,
+					Full: []string{"1", "0", "60", "1", "1:0-100"},
+				},
+				{
+					Chr: "1", Start: 60, Stop: 100,
+					Full: []string{"1", "60", "100", "2", "1:0-100"},
+				},
+				{
+					Chr: "2", Start: 0, Stop: 50,
+					Full: []string{"2", "0", "50", "1", "2:0-50"},
+				},
+			},
+		},
+		{
+			testing: "genome without fasta index",
+			bed: Bedfile{
+				MakeWindows:     GenomeMW,
+				MakeWindowsSize: 60,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.MakeWindowsLines()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail {
+				if diff := deep.Equal(tc.expectedLines, tc.bed.Lines); diff != nil {
+					t.Error("expected VS received lines", diff)
+				}
+			}
+		})
+	}
+}
@@ -2,69 +2,207 @@ package bed
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 )
 
+// Region created by merging lines, the values of the columns
+// that are aggregated (--merge-cols) and the coordinates of the
+// merged lines are kept until the region is finished
+type mergedRegion struct {
+	Line
+	colValues [][]string
+	coords    []string
+	// Smallest start and largest stop of the merged lines before padding
+	originalStart int
+	originalStop  int
+}
+
+// Padded line together with the line before padding
+type paddedLine struct {
+	padded   Line
+	original Line
+}
+
 // Merge and pad lines in bed file
 func (bf *Bedfile) MergeAndPadLines() error {
-	var merged Line
+	var merged mergedRegion
 	var mergedLines []Line
 	var chrNotInLengthMap []string
-	for i, l := range mergeSort(bf.Lines) {
-		// Pad line
-		if bf.Padding != 0 {
+	var clipped []clippedLine
+	// Nothing to merge, e.g. if the input is empty
+	if len(bf.Lines) == 0 {
+		return nil
+	}
+	if err := bf.verifyMergeCols(bf.Lines[0]); err != nil {
+		return err
+	}
+	// Pad the lines before sorting them, as the padding
+	// can change the order of the starts
+	lines := make([]paddedLine, 0, len(bf.Lines))
+	for _, l := range bf.Lines {
+		original := l
+		if bf.PaddingSelected() {
 			var err error
-			l, chrNotInLengthMap, err = bf.padAccordingToPaddingType(l, chrNotInLengthMap)
+			l, chrNotInLengthMap, clipped, err = bf.padAndRecordClipping(l, chrNotInLengthMap, clipped)
 			if err != nil {
 				return err
 			}
 		}
+		lines = append(lines, paddedLine{padded: l, original: original})
+	}
+	slices.SortStableFunc(lines, func(a, b paddedLine) int {
+		return mergeCompare(a.padded, b.padded)
+	})
 
+	for i, pl := range lines {
+		l, original := pl.padded, pl.original
 		// Merge lines
 		// If the lines are overlapping or touching merge them
-		if i != 0 &&
-			merged.Chr == l.Chr &&
-			merged.Strand == l.Strand &&
-			merged.Feat == l.Feat &&
-			merged.Stop+bf.Overlap >= l.Start-1 {
-			// Set new stop if it is later than the
-			// merged stop
-			if l.Stop > merged.Stop {
-				merged.Stop = l.Stop
-				merged.Full[stopIdx] = strconv.Itoa(l.Stop)
-			}
-			// Join information in the optional columns
-			if len(l.Full) > stopIdx+1 {
-				for idx, col := range l.Full[stopIdx+1:] {
-					mIdx := idx + stopIdx + 1
-					if !stringInSlice(strings.Split(merged.Full[mIdx], ","), col) {
-						merged.Full[mIdx] = fmt.Sprintf("%s,%s", merged.Full[mIdx], col)
-					}
-				}
-			}
+		if i != 0 && bf.isMergeable(merged.Line, l) {
+			merged = bf.mergeLine(merged, l, original)
 		} else {
 			// If we are not on the first line append merged to MergedLines
 			if i != 0 {
-				mergedLines = append(mergedLines, merged)
+				finished, err := bf.finishMergedRegion(merged)
+				if err != nil {
+					return err
+				}
+				mergedLines = append(mergedLines, finished)
 			}
 			// Create new merged line
-			merged = Line{
-				Chr: l.Chr, Start: l.Start, Stop: l.Stop,
-				Strand: l.Strand, Feat: l.Feat,
-				Full: l.Full,
-			}
+			merged = bf.newMergedRegion(l, original)
 		}
 	}
 	// If we have been padding print padding warnings
-	if bf.Padding != 0 {
+	if bf.PaddingSelected() {
 		bf.paddingWarnings(chrNotInLengthMap)
+		if err := bf.reportClipping(clipped); err != nil {
+			return err
+		}
 	}
 	// Replace lines in Bedfile
-	bf.Lines = append(mergedLines, merged)
+	finished, err := bf.finishMergedRegion(merged)
+	if err != nil {
+		return err
+	}
+	bf.Lines = append(mergedLines, finished)
 	return nil
 }
 
+// Verify that the columns to aggregate are in the line
+func (bf Bedfile) verifyMergeCols(l Line) error {
+	for _, col := range bf.MergeCols {
+		if col > len(l.Full)-1 {
+			return fmt.Errorf("given merge column, %d, is outside bed file (nr columns=%d)", col+1, len(l.Full))
+		}
+	}
+	return nil
+}
+
+// Returns true if the line is overlapping or touching the merged
+// line, and they are on the same chromosome, strand and feature
+func (bf Bedfile) isMergeable(merged, l Line) bool {
+	return merged.Chr == l.Chr &&
+		merged.Strand == l.Strand &&
+		merged.Feat == l.Feat &&
+		merged.Stop+bf.Overlap >= l.Start-1
+}
+
+// Create a new merged region from a line, original is
+// the line before padding
+func (bf Bedfile) newMergedRegion(l, original Line) mergedRegion {
+	merged := mergedRegion{
+		Line: Line{
+			Chr: l.Chr, Start: l.Start, Stop: l.Stop,
+			Strand: l.Strand, Feat: l.Feat,
+			Full: l.Full,
+		},
+		coords:        []string{coordinates(original)},
+		originalStart: original.Start,
+		originalStop:  original.Stop,
+	}
+	for _, col := range bf.MergeCols {
+		merged.colValues = append(merged.colValues, []string{l.Full[col]})
+	}
+	return merged
+}
+
+// Merge line into the merged region, original is
+// the line before padding
+func (bf Bedfile) mergeLine(merged mergedRegion, l, original Line) mergedRegion {
+	merged.coords = append(merged.coords, coordinates(original))
+	merged.originalStart = min(merged.originalStart, original.Start)
+	merged.originalStop = max(merged.originalStop, original.Stop)
+	// Set new start if it is earlier than the merged start,
+	// which can happen if the lines have been padded differently
+	if l.Start < merged.Start {
+		merged.Start = l.Start
+		merged.Full[startIdx] = strconv.Itoa(l.Start)
+	}
+	// Set new stop if it is later than the
+	// merged stop
+	if l.Stop > merged.Stop {
+		merged.Stop = l.Stop
+		merged.Full[stopIdx] = strconv.Itoa(l.Stop)
+	}
+	// Keep values of the columns to aggregate
+	for i, col := range bf.MergeCols {
+		merged.colValues[i] = append(merged.colValues[i], l.Full[col])
+	}
+	// Join information in the other optional columns
+	if len(l.Full) > stopIdx+1 {
+		for idx, col := range l.Full[stopIdx+1:] {
+			mIdx := idx + stopIdx + 1
+			if intInSlice(bf.MergeCols, mIdx) {
+				continue
+			}
+			if !stringInSlice(strings.Split(merged.Full[mIdx], ","), col) {
+				merged.Full[mIdx] = fmt.Sprintf("%s,%s", merged.Full[mIdx], col)
+			}
+		}
+	}
+	return merged
+}
+
+// Aggregate the values of the merge columns
+func (bf Bedfile) finishMergedRegion(merged mergedRegion) (Line, error) {
+	for i, col := range bf.MergeCols {
+		operation := bf.MergeOps[0]
+		if len(bf.MergeOps) > 1 {
+			operation = bf.MergeOps[i]
+		}
+		aggregated, err := aggregate(operation, merged.colValues[i])
+		if err != nil {
+			return Line{}, fmt.Errorf("can not use %s on column %d in region %s:%d-%d: %w",
+				operation, col+1, merged.Chr, merged.Start, merged.Stop, err)
+		}
+		merged.Full[col] = aggregated
+	}
+	// Append count and coordinates of the merged lines
+	if bf.MergeCount || bf.MergeCoords || bf.OriginalCoords {
+		merged.Full = slices.Clone(merged.Full)
+	}
+	if bf.MergeCount {
+		merged.Full = append(merged.Full, strconv.Itoa(len(merged.coords)))
+	}
+	if bf.MergeCoords {
+		merged.Full = append(merged.Full, strings.Join(merged.coords, ","))
+	}
+	if bf.OriginalCoords {
+		merged.Full = append(merged.Full,
+			strconv.Itoa(merged.originalStart), strconv.Itoa(merged.originalStop),
+			strings.Join(merged.coords, ","))
+	}
+	return merged.Line, nil
+}
+
+// Coordinates of a line in the format chr:start-stop
+func coordinates(l Line) string {
+	return fmt.Sprintf("%s:%d-%d", l.Chr, l.Start, l.Stop)
+}
+
 // Returns true or false depending on if the string
 // is in a slice
 func stringInSlice(slice []string, item string) bool {
@@ -75,3 +213,14 @@ func stringInSlice(slice []string, item string) bool {
 	}
 	return false
 }
+
+// Returns true or false depending on if the int
+// is in a slice
+func intInSlice(slice []int, item int) bool {
+	for _, i := range slice {
+		if item == i {
+			return true
+		}
+	}
+	return false
+}
@@ -153,6 +153,8 @@ var testMergeFull = []Line{
 	},
 }
 
+var testPadRight = 5
+
 func TestMergeAndPadLines(t *testing.T) {
 	t.Parallel()
 	type testCase struct {
@@ -499,6 +501,422 @@ func TestMergeAndPadLines(t *testing.T) {
 				},
 			},
 		},
+		{
+			testing: "merge cols with one merge op",
+			bed: Bedfile{
+				MergeCols: []int{4 - 1, 5 - 1},
+				MergeOps:  []string{SumCO},
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 4,
+						Full: []string{"1", "1", "4", "10", "1.5", "A"},
+					},
+					{
+						Chr: "1", Start: 3, Stop: 8,
+						Full: []string{"1", "3", "8", "20", "2", "A"},
+					},
+					{
+						Chr: "1", Start: 20, Stop: 30,
+						Full: []string{"1", "20", "30", "5", "1", "B"},
+					},
+				},
+			},
+			expectedBed: Bedfile{
+				MergeCols: []int{4 - 1, 5 - 1},
+				MergeOps:  []string{SumCO},
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 8,
+						Full: []string{"1", "1", "8", "30", "3.5", "A"},
+					},
+					{
+						Chr: "1", Start: 20, Stop: 30,
+						Full: []string{"1", "20", "30", "5", "1", "B"},
+					},
+				},
+			},
+		},
+		{
+			testing: "merge cols with one merge op per col",
+			bed: Bedfile{
+				MergeCols: []int{4 - 1, 5 - 1, 6 - 1},
+				MergeOps:  []string{MedianCO, CountCO, CollapseCO},
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 4,
+						Full: []string{"1", "1", "4", "10", "x", "A", "g1"},
+					},
+					{
+						Chr: "1", Start: 3, Stop: 8,
+						Full: []string{"1", "3", "8", "20", "y", "A", "g1"},
+					},
+					{
+						Chr: "1", Start: 5, Stop: 9,
+						Full: []string{"1", "5", "9", "25", "z", "B", "g2"},
+					},
+				},
+			},
+			expectedBed: Bedfile{
+				MergeCols: []int{4 - 1, 5 - 1, 6 - 1},
+				MergeOps:  []string{MedianCO, CountCO, CollapseCO},
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 9,
+						Full: []string{"1", "1", "9", "20", "3", "A,A,B", "g1,g2"},
+					},
+				},
+			},
+		},
+		{
+			testing: "numeric merge op on non-numeric column",
+			bed: Bedfile{
+				MergeCols: []int{4 - 1},
+				MergeOps:  []string{MeanCO},
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 4,
+						Full: []string{"1", "1", "4", "A"},
+					},
+				},
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "merge col outside bed file",
+			bed: Bedfile{
+				MergeCols: []int{5 - 1},
+				MergeOps:  []string{SumCO},
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 4,
+						Full: []string{"1", "1", "4", "1"},
+					},
+				},
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "merge count and coords, padding = 1",
+			bed: Bedfile{
+				PaddingType:  SafePT,
+				Padding:      1,
+				MergeCount:   true,
+				MergeCoords:  true,
+				chrLengthMap: testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 4,
+						Full: []string{"1", "1", "4", "A"},
+					},
+					{
+						Chr: "1", Start: 6, Stop: 8,
+						Full: []string{"1", "6", "8", "A"},
+					},
+					{
+						Chr: "1", Start: 20, Stop: 30,
+						Full: []string{"1", "20", "30", "B"},
+					},
+				},
+			},
+			expectedBed: Bedfile{
+				PaddingType:  SafePT,
+				Padding:      1,
+				MergeCount:   true,
+				MergeCoords:  true,
+				chrLengthMap: testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 0, Stop: 9,
+						Full: []string{"1", "0", "9", "A", "2", "1:1-4,1:6-8"},
+					},
+					{
+						Chr: "1", Start: 19, Stop: 31,
+						Full: []string{"1", "19", "31", "B", "1", "1:20-30"},
+					},
+				},
+			},
+		},
+		{
+			testing: "original coords, padding = 1",
+			bed: Bedfile{
+				PaddingType:    SafePT,
+				Padding:        1,
+				OriginalCoords: true,
+				chrLengthMap:   testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 4,
+						Full: []string{"1", "1", "4"},
+					},
+					{
+						Chr: "1", Start: 3, Stop: 8,
+						Full: []string{"1", "3", "8"},
+					},
+					{
+						Chr: "1", Start: 20, Stop: 30,
+						Full: []string{"1", "20", "30"},
+					},
+				},
+			},
+			expectedBed: Bedfile{
+				PaddingType:    SafePT,
+				Padding:        1,
+				OriginalCoords: true,
+				chrLengthMap:   testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 0, Stop: 9,
+						Full: []string{"1", "0", "9", "1", "8", "1:1-4,1:3-8"},
+					},
+					{
+						Chr: "1", Start: 19, Stop: 31,
+						Full: []string{"1", "19", "31", "20", "30", "1:20-30"},
+					},
+				},
+			},
+		},
+		{
+			testing: "pad right only, padding = 0",
+			bed: Bedfile{
+				PaddingType:  SafePT,
+				PadRight:     &testPadRight,
+				chrLengthMap: testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 4,
+						Full: []string{"1", "1", "4"},
+					},
+					{
+						Chr: "1", Start: 10, Stop: 20,
+						Full: []string{"1", "10", "20"},
+					},
+					{
+						Chr: "1", Start: 30, Stop: 40,
+						Full: []string{"1", "30", "40"},
+					},
+				},
+			},
+			expectedBed: Bedfile{
+				PaddingType:  SafePT,
+				PadRight:     &testPadRight,
+				chrLengthMap: testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 25,
+						Full: []string{"1", "1", "25"},
+					},
+					{
+						Chr: "1", Start: 30, Stop: 45,
+						Full: []string{"1", "30", "45"},
+					},
+				},
+			},
+		},
+		{
+			testing: "fractional padding with maximum",
+			bed: Bedfile{
+				PaddingType:     SafePT,
+				PaddingFraction: 0.5,
+				PaddingMax:      2,
+				chrLengthMap:    testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 4,
+						Full: []string{"1", "1", "4"},
+					},
+					{
+						Chr: "1", Start: 10, Stop: 20,
+						Full: []string{"1", "10", "20"},
+					},
+					{
+						Chr: "1", Start: 30, Stop: 40,
+						Full: []string{"1", "30", "40"},
+					},
+				},
+			},
+			expectedBed: Bedfile{
+				PaddingType:     SafePT,
+				PaddingFraction: 0.5,
+				PaddingMax:      2,
+				chrLengthMap:    testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 0, Stop: 6,
+						Full: []string{"1", "0", "6"},
+					},
+					{
+						Chr: "1", Start: 8, Stop: 22,
+						Full: []string{"1", "8", "22"},
+					},
+					{
+						Chr: "1", Start: 28, Stop: 42,
+						Full: []string{"1", "28", "42"},
+					},
+				},
+			},
+		},
+		{
+			testing: "fractional padding",
+			bed: Bedfile{
+				PaddingType:     SafePT,
+				PaddingFraction: 0.5,
+				chrLengthMap:    testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 4,
+						Full: []string{"1", "1", "4"},
+					},
+					{
+						Chr: "1", Start: 10, Stop: 20,
+						Full: []string{"1", "10", "20"},
+					},
+					{
+						Chr: "1", Start: 30, Stop: 40,
+						Full: []string{"1", "30", "40"},
+					},
+				},
+			},
+			expectedBed: Bedfile{
+				PaddingType:     SafePT,
+				PaddingFraction: 0.5,
+				chrLengthMap:    testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 0, Stop: 45,
+						Full: []string{"1", "0", "45"},
+					},
+				},
+			},
+		},
+		{
+			testing: "fractional padding changing the order of the starts",
+			bed: Bedfile{
+				PaddingType:     ForcePT,
+				PaddingFraction: 1,
+				chrLengthMap:    testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "2", Start: 100, Stop: 110,
+						Full: []string{"2", "100", "110"},
+					},
+					{
+						Chr: "2", Start: 101, Stop: 141,
+						Full: []string{"2", "101", "141"},
+					},
+				},
+			},
+			expectedBed: Bedfile{
+				PaddingType:     ForcePT,
+				PaddingFraction: 1,
+				chrLengthMap:    testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "2", Start: 61, Stop: 181,
+						Full: []string{"2", "61", "181"},
+					},
+				},
+			},
+		},
+		{
+			testing: "padding column changing the order of the starts",
+			bed: Bedfile{
+				PaddingType:  ForcePT,
+				PaddingCol:   3,
+				chrLengthMap: testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "4", Start: 100, Stop: 110,
+						Full: []string{"4", "100", "110", "0"},
+					},
+					{
+						Chr: "4", Start: 200, Stop: 210,
+						Full: []string{"4", "200", "210", "0"},
+					},
+					{
+						Chr: "4", Start: 205, Stop: 206,
+						Full: []string{"4", "205", "206", "150"},
+					},
+				},
+			},
+			expectedBed: Bedfile{
+				PaddingType:  ForcePT,
+				PaddingCol:   3,
+				chrLengthMap: testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "4", Start: 55, Stop: 356,
+						Full: []string{"4", "55", "356", "150,0"},
+					},
+				},
+			},
+		},
+		{
+			testing: "minimum size changing the order of the starts",
+			bed: Bedfile{
+				PaddingType:  ForcePT,
+				MinSize:      20,
+				chrLengthMap: testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "4", Start: 100, Stop: 110,
+						Full: []string{"4", "100", "110"},
+					},
+					{
+						Chr: "4", Start: 101, Stop: 103,
+						Full: []string{"4", "101", "103"},
+					},
+				},
+			},
+			expectedBed: Bedfile{
+				PaddingType:  ForcePT,
+				MinSize:      20,
+				chrLengthMap: testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "4", Start: 92, Stop: 115,
+						Full: []string{"4", "92", "115"},
+					},
+				},
+			},
+		},
+		{
+			testing: "shrinking changing the order of the starts",
+			bed: Bedfile{
+				PaddingType:  ForcePT,
+				Shrink:       60,
+				chrLengthMap: testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "4", Start: 100, Stop: 300,
+						Full: []string{"4", "100", "300"},
+					},
+					{
+						Chr: "4", Start: 101, Stop: 111,
+						Full: []string{"4", "101", "111"},
+					},
+				},
+			},
+			expectedBed: Bedfile{
+				PaddingType:  ForcePT,
+				Shrink:       60,
+				chrLengthMap: testChrLengthMap,
+				Lines: []Line{
+					{
+						Chr: "4", Start: 105, Stop: 106,
+						Full: []string{"4", "105", "106"},
+					},
+					{
+						Chr: "4", Start: 160, Stop: 240,
+						Full: []string{"4", "160", "240"},
+					},
+				},
+			},
+		},
+		{
+			testing:     "no lines",
+			bed:         Bedfile{},
+			expectedBed: Bedfile{},
+		},
 		{
 			testing: "padding=5 && overlap=-1",
 			bed: Bedfile{
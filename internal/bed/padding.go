@@ -2,8 +2,10 @@ package bed
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"strconv"
+	"strings"
 )
 
 // Padding types
@@ -14,18 +16,22 @@ var ForcePT = "force" // Will pad everything, fasta index optional (will warn if
 // Pad regions
 func (bf *Bedfile) PadLines() error {
 	var chrNotInLengthMap []string
+	var clipped []clippedLine
 	var err error
 
 	// Loop over and pad lines
 	for i, line := range bf.Lines {
-		bf.Lines[i], chrNotInLengthMap, err = bf.padAccordingToPaddingType(line, chrNotInLengthMap)
+		bf.Lines[i], chrNotInLengthMap, clipped, err = bf.padAndRecordClipping(line, chrNotInLengthMap, clipped)
 		if err != nil {
 			return err
 		}
+		if bf.OriginalCoords {
+			bf.Lines[i] = withOriginalCoordinates(bf.Lines[i], line)
+		}
 	}
 	// Warn depending on padding type
 	bf.paddingWarnings(chrNotInLengthMap)
-	return nil
+	return bf.reportClipping(clipped)
 }
 
 // Handle missing chromosome in chromosome length map
@@ -46,11 +52,21 @@ func (bf Bedfile) padAccordingToPaddingType(line Line, chrNotInLengthMap []strin
 		case LaxPT:
 			paddedLine = line
 		}
-		chrNotInLengthMap = append(chrNotInLengthMap, line.Chr)
+		// Only keep each chromosome once, as this can be called
+		// for every line when streaming
+		if !stringInSlice(chrNotInLengthMap, line.Chr) {
+			chrNotInLengthMap = append(chrNotInLengthMap, line.Chr)
+		}
 	}
 	return paddedLine, chrNotInLengthMap, nil
 }
 
+// Copy padded line with the start and stop
+// of the original line appended
+func withOriginalCoordinates(l, original Line) Line {
+	return withColumns(l, Line{Full: []string{strconv.Itoa(original.Start), strconv.Itoa(original.Stop)}})
+}
+
 // Handle warnings depending on padding types
 func (bf Bedfile) paddingWarnings(chrNotInLengthMap []string) {
 	if len(chrNotInLengthMap) > 0 {
@@ -58,7 +74,12 @@ func (bf Bedfile) paddingWarnings(chrNotInLengthMap []string) {
 			sortAndDeduplicateListOfStrings(chrNotInLengthMap), bf.FastaIdx)
 		switch bf.PaddingType {
 		case LaxPT:
-			fmt.Fprintf(os.Stderr, "warning: %s, no padding was added to regions on these chromosomes\n", warnMsg)
+			// Regions without padding have no flanks
+			if bf.FlankSelected() {
+				fmt.Fprintf(os.Stderr, "warning: %s, no flanks were created for regions on these chromosomes, so they were removed\n", warnMsg)
+			} else {
+				fmt.Fprintf(os.Stderr, "warning: %s, no padding was added to regions on these chromosomes\n", warnMsg)
+			}
 		case ForcePT:
 			if bf.FastaIdx != "" {
 				fmt.Fprintf(os.Stderr, "warning: %s, regions on these chromosomes were still padded\n", warnMsg)
@@ -69,8 +90,191 @@ func (bf Bedfile) paddingWarnings(chrNotInLengthMap []string) {
 	}
 }
 
+// Returns true if the regions should be padded, when flanking
+// the padding is used as the size of the flanks instead
+func (bf Bedfile) PaddingSelected() bool {
+	return bf.paddingSizeSelected() && !bf.FlankSelected()
+}
+
+// Returns true if any padding size is selected
+func (bf Bedfile) paddingSizeSelected() bool {
+	return bf.Padding != 0 || bf.PaddingFraction != 0 || bf.PaddingCol != 0 || bf.MinSize != 0 ||
+		bf.Shrink != 0 || bf.Shift != 0 || bf.PadLeft != nil || bf.PadRight != nil ||
+		bf.PadUpstream != nil || bf.PadDownstream != nil
+}
+
+// Returns true if flanking is selected
+func (bf Bedfile) FlankSelected() bool {
+	return bf.Flank != "" && bf.Flank != NoneFT
+}
+
+// Padding to the left and right of the line. Upstream and downstream
+// padding is used for lines on the + or - strand, otherwise left and
+// right padding is used, all falling back to --padding
+func (bf Bedfile) paddingLeftAndRight(l Line) (int, int, error) {
+	padding, err := bf.linePadding(l)
+	if err != nil {
+		return 0, 0, err
+	}
+	left, right := padding, padding
+	if bf.PadLeft != nil {
+		left = *bf.PadLeft
+	}
+	if bf.PadRight != nil {
+		right = *bf.PadRight
+	}
+	if bf.StrandCol == 0 {
+		return left, right, nil
+	}
+	switch {
+	case isPlusStrand(l.Strand):
+		if bf.PadUpstream != nil {
+			left = *bf.PadUpstream
+		}
+		if bf.PadDownstream != nil {
+			right = *bf.PadDownstream
+		}
+	case isMinusStrand(l.Strand):
+		if bf.PadUpstream != nil {
+			right = *bf.PadUpstream
+		}
+		if bf.PadDownstream != nil {
+			left = *bf.PadDownstream
+		}
+	}
+	return left, right, nil
+}
+
+// Padding of the line, from the padding column if it is set
+// and not empty, otherwise from --padding
+func (bf Bedfile) linePadding(l Line) (int, error) {
+	padding, fraction := bf.Padding, bf.PaddingFraction
+	if bf.PaddingCol > stopIdx && bf.PaddingCol < len(l.Full) && !emptyPadding(l.Full[bf.PaddingCol]) {
+		var err error
+		padding, fraction, err = parsePadding(l.Full[bf.PaddingCol])
+		if err != nil {
+			return 0, fmt.Errorf("padding column %w: %v", err, l.Full)
+		}
+	}
+	if fraction != 0 {
+		return bf.fractionalPadding(l, fraction), nil
+	}
+	return padding, nil
+}
+
+// Returns true if the padding column is empty
+func emptyPadding(padding string) bool {
+	return padding == "" || padding == "."
+}
+
+// Parse padding given either in bp or as a fraction
+// of the region length followed by x (e.g. 0.1x)
+func parsePadding(padding string) (int, float64, error) {
+	if fraction, ok := strings.CutSuffix(padding, "x"); ok {
+		f, err := strconv.ParseFloat(fraction, 64)
+		if err != nil || f <= 0 {
+			return 0, 0, fmt.Errorf("must be a positive fraction followed by x: %q", padding)
+		}
+		return 0, f, nil
+	}
+	bp, err := strconv.Atoi(padding)
+	if err != nil {
+		return 0, 0, fmt.Errorf("must be an integer or a fraction followed by x: %q", padding)
+	}
+	return bp, 0, nil
+}
+
+// Padding as a fraction of the region length, rounded to the
+// nearest bp and kept within the minimum and maximum padding
+func (bf Bedfile) fractionalPadding(l Line, fraction float64) int {
+	padding := int(math.Round(fraction * float64(bf.regionLength(l))))
+	padding = max(padding, bf.PaddingMin)
+	if bf.PaddingMax != 0 {
+		padding = min(padding, bf.PaddingMax)
+	}
+	return padding
+}
+
+// Returns true if the strand is +, +1 or 1
+func isPlusStrand(strand string) bool {
+	return strand == "+" || strand == "+1" || strand == "1"
+}
+
+// Returns true if the strand is - or -1
+func isMinusStrand(strand string) bool {
+	return strand == "-" || strand == "-1"
+}
+
 // Pad single line
 func (bf Bedfile) padLine(l Line) (Line, bool, error) {
+	moved, left, right, err := bf.paddingOf(l)
+	if err != nil {
+		return Line{}, false, err
+	}
+	return bf.padLineWith(moved, left, right)
+}
+
+// The line after shrinking and shifting, and the
+// padding to add to the left and right of it
+func (bf Bedfile) paddingOf(l Line) (Line, int, int, error) {
+	left, right, err := bf.paddingLeftAndRight(l)
+	if err != nil {
+		return Line{}, 0, 0, err
+	}
+	moved := bf.shiftLine(bf.shrinkLine(l))
+	extendLeft, extendRight := bf.minSizeExtension(moved)
+	return moved, left + extendLeft, right + extendRight, nil
+}
+
+// Shrink the line from both ends, lines that are too short
+// are shrunk to the base in their middle
+func (bf Bedfile) shrinkLine(l Line) Line {
+	if bf.Shrink == 0 {
+		return l
+	}
+	if bf.regionLength(l)-2*bf.Shrink > 0 {
+		l.Start += bf.Shrink
+		l.Stop -= bf.Shrink
+		return l
+	}
+	l.Start += (bf.regionLength(l) - 1) / 2
+	l.Stop = l.Start + 1 - bf.FirstBase
+	return l
+}
+
+// Shift the line, according to the strand if selected. Lines
+// shifted beyond the chromosome borders keep at least one base
+// at the border, the rest is clipped when padding
+func (bf Bedfile) shiftLine(l Line) Line {
+	if bf.Shift == 0 {
+		return l
+	}
+	shift := bf.Shift
+	if bf.ShiftStrand && isMinusStrand(l.Strand) {
+		shift = -shift
+	}
+	l.Start += shift
+	l.Stop += shift
+	l.Stop = max(l.Stop, 1)
+	if chrLength, ok := bf.chrLengthMap[l.Chr]; ok {
+		l.Start = min(l.Start, chrLength-1+bf.FirstBase)
+	}
+	return l
+}
+
+// Extension of each side of the line needed to reach the minimum
+// size, split evenly around the centre of the line with the odd
+// base added to the right
+func (bf Bedfile) minSizeExtension(l Line) (int, int) {
+	missing := bf.MinSize - bf.regionLength(l)
+	if missing <= 0 {
+		return 0, 0
+	}
+	return missing / 2, missing - missing/2
+}
+
+// Pad single line with different padding to the left and right
+func (bf Bedfile) padLineWith(l Line, left, right int) (Line, bool, error) {
 	var err error
 	// Deep line to make sure we do not overwrite
 	fullLineCopy := make([]string, len(l.Full))
@@ -81,11 +285,16 @@ func (bf Bedfile) padLine(l Line) (Line, bool, error) {
 		Full: fullLineCopy,
 	}
 	// Line
-	line.Start = line.Start - bf.Padding
-	line.Stop = line.Stop + bf.Padding
-	// Make sure we do not end up with a flipped region if negative padding has been used
-	if line.Start >= line.Stop {
-		err = fmt.Errorf("padding with %d will results in start >= stop for: %v", bf.Padding, line.Full)
+	line.Start = line.Start - left
+	line.Stop = line.Stop + right
+	// Make sure we do not end up with a flipped region if negative padding has been used,
+	// lines without length that are not padded (e.g. only shifted) are kept as they are
+	if (left != 0 || right != 0) && bf.regionLength(line) <= 0 {
+		if left == right {
+			err = fmt.Errorf("padding with %d will results in start >= stop for: %v", left, line.Full)
+		} else {
+			err = fmt.Errorf("padding with %d to the left and %d to the right will results in start >= stop for: %v", left, right, line.Full)
+		}
 		return Line{}, false, err
 	}
 	// Make sure that the padding does not exceed the chromosome limits
@@ -41,6 +41,32 @@ func TestPadLines(t *testing.T) {
 		shouldFail  bool
 	}
 	testCases := []testCase{
+		{
+			testing: "padding with original coords",
+			bed: Bedfile{
+				PaddingType:    SafePT,
+				Padding:        10,
+				OriginalCoords: true,
+				Lines:          deepCopyLines(testLinesToPad[:2]),
+				chrLengthMap:   testChrLengthMap,
+			},
+			expectedBed: Bedfile{
+				PaddingType:    SafePT,
+				Padding:        10,
+				OriginalCoords: true,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 40, Stop: 61,
+						Full: []string{"1", "40", "61", "50", "51"},
+					},
+					{
+						Chr: "2", Start: 140, Stop: 161,
+						Full: []string{"2", "140", "161", "150", "151"},
+					},
+				},
+				chrLengthMap: testChrLengthMap,
+			},
+		},
 		{
 			testing: "padding within chromosome, no missing, paddingType=safe",
 			bed: Bedfile{
@@ -456,6 +482,21 @@ func TestPadLineAccordingToPaddingType(t *testing.T) {
 			},
 			expectedMisschrMap: []string{"1", "2"},
 		},
+		{
+			testing: "chromosome already missing, paddingType=force",
+			bed: Bedfile{
+				PaddingType: ForcePT,
+				Padding:     1000,
+				FirstBase:   1,
+			},
+			line:       deepCopyLine(testLinesToPad[1]),
+			missChrMap: []string{"1", "2"},
+			expectedPaddedLine: Line{
+				Chr: "2", Start: 1, Stop: 1151,
+				Full: []string{"2", "1", "1151"},
+			},
+			expectedMisschrMap: []string{"1", "2"},
+		},
 		{
 			testing:    "padding type does not exist",
 			bed:        Bedfile{PaddingType: "test"},
@@ -641,6 +682,210 @@ func TestPadLine(t *testing.T) {
 			},
 			shouldFail: true,
 		},
+		{
+			testing: "shrink",
+			bed: Bedfile{
+				Shrink: 5,
+				chrLengthMap: map[string]int{
+					"1": 100,
+				},
+			},
+			line: Line{
+				Chr: "1", Start: 40, Stop: 70,
+				Full: []string{"1", "40", "70"},
+			},
+			expectedLine: Line{
+				Chr: "1", Start: 45, Stop: 65,
+				Full: []string{"1", "45", "65"},
+			},
+			expectedChrInMap: true,
+		},
+		{
+			testing: "shrink to the middle base",
+			bed: Bedfile{
+				Shrink: 20,
+				chrLengthMap: map[string]int{
+					"1": 100,
+				},
+			},
+			line: Line{
+				Chr: "1", Start: 40, Stop: 70,
+				Full: []string{"1", "40", "70"},
+			},
+			expectedLine: Line{
+				Chr: "1", Start: 54, Stop: 55,
+				Full: []string{"1", "54", "55"},
+			},
+			expectedChrInMap: true,
+		},
+		{
+			testing: "shrink to the middle base, first base 1",
+			bed: Bedfile{
+				Shrink:    20,
+				FirstBase: 1,
+				chrLengthMap: map[string]int{
+					"1": 100,
+				},
+			},
+			line: Line{
+				Chr: "1", Start: 40, Stop: 70,
+				Full: []string{"1", "40", "70"},
+			},
+			expectedLine: Line{
+				Chr: "1", Start: 55, Stop: 55,
+				Full: []string{"1", "55", "55"},
+			},
+			expectedChrInMap: true,
+		},
+		{
+			testing: "shift to the right and pad",
+			bed: Bedfile{
+				Shift:   5,
+				Padding: 1,
+				chrLengthMap: map[string]int{
+					"1": 100,
+				},
+			},
+			line: Line{
+				Chr: "1", Start: 40, Stop: 70,
+				Full: []string{"1", "40", "70"},
+			},
+			expectedLine: Line{
+				Chr: "1", Start: 44, Stop: 76,
+				Full: []string{"1", "44", "76"},
+			},
+			expectedChrInMap: true,
+		},
+		{
+			testing: "shift according to strand",
+			bed: Bedfile{
+				Shift:       5,
+				ShiftStrand: true,
+				StrandCol:   3,
+				chrLengthMap: map[string]int{
+					"1": 100,
+				},
+			},
+			line: Line{
+				Chr: "1", Start: 40, Stop: 70, Strand: "-",
+				Full: []string{"1", "40", "70", "-"},
+			},
+			expectedLine: Line{
+				Chr: "1", Start: 35, Stop: 65, Strand: "-",
+				Full: []string{"1", "35", "65", "-"},
+			},
+			expectedChrInMap: true,
+		},
+		{
+			testing: "shift line without length",
+			bed: Bedfile{
+				Shift: 5,
+				chrLengthMap: map[string]int{
+					"1": 100,
+				},
+			},
+			line: Line{
+				Chr: "1", Start: 40, Stop: 40,
+				Full: []string{"1", "40", "40"},
+			},
+			expectedLine: Line{
+				Chr: "1", Start: 45, Stop: 45,
+				Full: []string{"1", "45", "45"},
+			},
+			expectedChrInMap: true,
+		},
+		{
+			testing: "shift beyond chromosome end",
+			bed: Bedfile{
+				Shift: 80,
+				chrLengthMap: map[string]int{
+					"1": 100,
+				},
+			},
+			line: Line{
+				Chr: "1", Start: 40, Stop: 70,
+				Full: []string{"1", "40", "70"},
+			},
+			expectedLine: Line{
+				Chr: "1", Start: 99, Stop: 100,
+				Full: []string{"1", "99", "100"},
+			},
+			expectedChrInMap: true,
+		},
+		{
+			testing: "shift beyond chromosome start",
+			bed: Bedfile{
+				Shift: -80,
+				chrLengthMap: map[string]int{
+					"1": 100,
+				},
+			},
+			line: Line{
+				Chr: "1", Start: 40, Stop: 70,
+				Full: []string{"1", "40", "70"},
+			},
+			expectedLine: Line{
+				Chr: "1", Start: 0, Stop: 1,
+				Full: []string{"1", "0", "1"},
+			},
+			expectedChrInMap: true,
+		},
+		{
+			testing: "minimum size, odd extension",
+			bed: Bedfile{
+				MinSize: 10,
+				chrLengthMap: map[string]int{
+					"1": 100,
+				},
+			},
+			line: Line{
+				Chr: "1", Start: 50, Stop: 57,
+				Full: []string{"1", "50", "57"},
+			},
+			expectedLine: Line{
+				Chr: "1", Start: 49, Stop: 59,
+				Full: []string{"1", "49", "59"},
+			},
+			expectedChrInMap: true,
+		},
+		{
+			testing: "minimum size together with padding, clamped to chromosome end",
+			bed: Bedfile{
+				Padding: 1,
+				MinSize: 10,
+				chrLengthMap: map[string]int{
+					"1": 100,
+				},
+			},
+			line: Line{
+				Chr: "1", Start: 96, Stop: 100,
+				Full: []string{"1", "96", "100"},
+			},
+			expectedLine: Line{
+				Chr: "1", Start: 92, Stop: 100,
+				Full: []string{"1", "92", "100"},
+			},
+			expectedChrInMap: true,
+		},
+		{
+			testing: "minimum size, first base 1, region long enough",
+			bed: Bedfile{
+				MinSize:   10,
+				FirstBase: 1,
+				chrLengthMap: map[string]int{
+					"1": 100,
+				},
+			},
+			line: Line{
+				Chr: "1", Start: 50, Stop: 59,
+				Full: []string{"1", "50", "59"},
+			},
+			expectedLine: Line{
+				Chr: "1", Start: 50, Stop: 59,
+				Full: []string{"1", "50", "59"},
+			},
+			expectedChrInMap: true,
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc
@@ -665,3 +910,226 @@ func TestPadLine(t *testing.T) {
 		})
 	}
 }
+
+func TestPadLineWith(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing      string
+		left         int
+		right        int
+		line         Line
+		expectedLine Line
+		shouldFail   bool
+	}
+	testCases := []testCase{
+		{
+			testing: "different padding to the left and right",
+			left:    5,
+			right:   20,
+			line: Line{
+				Chr: "1", Start: 50, Stop: 51,
+				Full: []string{"1", "50", "51"},
+			},
+			expectedLine: Line{
+				Chr: "1", Start: 45, Stop: 71,
+				Full: []string{"1", "45", "71"},
+			},
+		},
+		{
+			testing: "no padding to the left, clamped to the right",
+			left:    0,
+			right:   60,
+			line: Line{
+				Chr: "1", Start: 50, Stop: 51,
+				Full: []string{"1", "50", "51"},
+			},
+			expectedLine: Line{
+				Chr: "1", Start: 50, Stop: 100,
+				Full: []string{"1", "50", "100"},
+			},
+		},
+		{
+			testing: "negative padding resulting in start >= stop",
+			left:    0,
+			right:   -10,
+			line: Line{
+				Chr: "1", Start: 50, Stop: 55,
+				Full: []string{"1", "50", "55"},
+			},
+			shouldFail: true,
+		},
+	}
+	bed := Bedfile{chrLengthMap: testChrLengthMap}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			paddedLine, _, err := bed.padLineWith(tc.line, tc.left, tc.right)
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail {
+				if diff := deep.Equal(tc.expectedLine, paddedLine); diff != nil {
+					t.Error("expected VS received line", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestPaddingLeftAndRight(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing       string
+		bed           Bedfile
+		line          Line
+		expectedLeft  int
+		expectedRight int
+	}
+	one, two, three, four := 1, 2, 3, 4
+	testCases := []testCase{
+		{
+			testing:       "padding only",
+			bed:           Bedfile{Padding: 10},
+			line:          Line{Strand: "-"},
+			expectedLeft:  10,
+			expectedRight: 10,
+		},
+		{
+			testing: "left overrides padding",
+			bed: Bedfile{
+				Padding: 10,
+				PadLeft: &one,
+			},
+			expectedLeft:  1,
+			expectedRight: 10,
+		},
+		{
+			testing: "upstream and downstream on + strand",
+			bed: Bedfile{
+				StrandCol:     3,
+				PadLeft:       &one,
+				PadRight:      &two,
+				PadUpstream:   &three,
+				PadDownstream: &four,
+			},
+			line:          Line{Strand: "+1"},
+			expectedLeft:  3,
+			expectedRight: 4,
+		},
+		{
+			testing: "upstream and downstream on - strand",
+			bed: Bedfile{
+				StrandCol:     3,
+				PadLeft:       &one,
+				PadRight:      &two,
+				PadUpstream:   &three,
+				PadDownstream: &four,
+			},
+			line:          Line{Strand: "-"},
+			expectedLeft:  4,
+			expectedRight: 3,
+		},
+		{
+			testing: "only upstream on - strand",
+			bed: Bedfile{
+				StrandCol:   3,
+				Padding:     10,
+				PadUpstream: &three,
+			},
+			line:          Line{Strand: "-1"},
+			expectedLeft:  10,
+			expectedRight: 3,
+		},
+		{
+			testing: "fractional padding",
+			bed: Bedfile{
+				PaddingFraction: 0.1,
+			},
+			line:          Line{Start: 100, Stop: 255},
+			expectedLeft:  16,
+			expectedRight: 16,
+		},
+		{
+			testing: "fractional padding with minimum",
+			bed: Bedfile{
+				PaddingFraction: 0.1,
+				PaddingMin:      20,
+			},
+			line:          Line{Start: 100, Stop: 255},
+			expectedLeft:  20,
+			expectedRight: 20,
+		},
+		{
+			testing: "fractional padding with maximum and first base 1",
+			bed: Bedfile{
+				PaddingFraction: 0.5,
+				PaddingMax:      40,
+				FirstBase:       1,
+				PadLeft:         &one,
+			},
+			line:          Line{Start: 100, Stop: 149},
+			expectedLeft:  1,
+			expectedRight: 25,
+		},
+		{
+			testing: "padding col",
+			bed: Bedfile{
+				Padding:    10,
+				PaddingCol: 3,
+			},
+			line:          Line{Full: []string{"1", "100", "200", "25"}},
+			expectedLeft:  25,
+			expectedRight: 25,
+		},
+		{
+			testing: "fractional padding col with maximum",
+			bed: Bedfile{
+				Padding:    10,
+				PaddingCol: 3,
+				PaddingMax: 15,
+			},
+			line:          Line{Start: 100, Stop: 200, Full: []string{"1", "100", "200", "0.2x"}},
+			expectedLeft:  15,
+			expectedRight: 15,
+		},
+		{
+			testing: "empty padding col falls back to padding",
+			bed: Bedfile{
+				Padding:    10,
+				PaddingCol: 3,
+				PadLeft:    &one,
+			},
+			line:          Line{Full: []string{"1", "100", "200", ""}},
+			expectedLeft:  1,
+			expectedRight: 10,
+		},
+		{
+			testing: "unknown strand uses left and right",
+			bed: Bedfile{
+				StrandCol:     3,
+				PadLeft:       &one,
+				PadRight:      &two,
+				PadUpstream:   &three,
+				PadDownstream: &four,
+			},
+			line:          Line{Strand: "."},
+			expectedLeft:  1,
+			expectedRight: 2,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			left, right, err := tc.bed.paddingLeftAndRight(tc.line)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.expectedLeft != left || tc.expectedRight != right {
+				t.Errorf("expected left %d and right %d got %d and %d",
+					tc.expectedLeft, tc.expectedRight, left, right)
+			}
+		})
+	}
+}
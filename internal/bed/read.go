@@ -17,18 +17,33 @@ const (
 	stopIdx  = 2
 )
 
+// Input path used for reading from stdin
+const stdinPath = "-"
+
+// Lines matching this pattern at the top of the file are headers
+var headerPattern = regexp.MustCompile(`^(browser|track|#)`)
+
 // Opening and reading the bed files and optional fasta index file
+// Gzip and BGZF compressed bed files are decompressed while reading
 func (bf *Bedfile) Read() error {
+	if err := bf.openAndReadChrAliases(); err != nil {
+		return err
+	}
 	for _, input := range bf.Inputs {
-		bedFile, err := os.Open(input)
+		reader, bedFile, err := openBed(input)
 		if err != nil {
 			return err
 		}
 		defer bedFile.Close()
-		if err := bf.readBed(bedFile); err != nil {
+		if err := bf.readBed(reader); err != nil {
 			return fmt.Errorf("can't read bed file %s: %q", input, err)
 		}
 	}
+	return bf.openAndReadFastaIdx()
+}
+
+// Opening and reading the fasta index file if it is set
+func (bf *Bedfile) openAndReadFastaIdx() error {
 	if bf.FastaIdx != "" {
 		fastaIdxFile, err := os.Open(bf.FastaIdx)
 		if err != nil {
@@ -42,20 +57,57 @@ func (bf *Bedfile) Read() error {
 	return nil
 }
 
+// Open input file, or stdin if the input is "-"
+func openInput(input string) (*os.File, error) {
+	if input == stdinPath {
+		return os.Stdin, nil
+	}
+	return os.Open(input)
+}
+
+// Open bed file and decompress it if it is gzip compressed
+// Note that the returned file has to be closed by the caller
+func openBed(input string) (io.Reader, *os.File, error) {
+	bedFile, err := openInput(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader, err := decompressIfGzipped(bedFile)
+	if err != nil {
+		bedFile.Close()
+		return nil, nil, fmt.Errorf("can't decompress bed file %s: %q", input, err)
+	}
+	return reader, bedFile, nil
+}
+
 // Reading the bed file
 func (bf *Bedfile) readBed(file io.Reader) error {
-	var err error
 	var expectedNrOfCols int
 
-	minNrCols := 3
-	headerPattern := regexp.MustCompile(`^(browser|track|#)`)
-	strandPattern := regexp.MustCompile(`^(\.|\+|-|\+1|-1|1)$`)
-
 	// If there is already content in bf save the expectedNrOfCols
 	if len(bf.Lines) != 0 {
 		expectedNrOfCols = len(bf.Lines[0].Full)
 	}
 
+	_, err := bf.scanBed(file, expectedNrOfCols, func(l Line) error {
+		bf.Lines = append(bf.Lines, l)
+		return nil
+	})
+	return err
+}
+
+// Scanning the bed file and passing each line to handleLine
+//
+// expectedNrOfCols should be 0 if no lines have been read
+// before, in that case headers are allowed at the top of the
+// file. The number of columns of the lines is returned so that
+// it can be passed on when scanning the next file
+func (bf *Bedfile) scanBed(file io.Reader, expectedNrOfCols int, handleLine func(Line) error) (int, error) {
+	var err error
+
+	minNrCols := 3
+	strandPattern := regexp.MustCompile(`^(\.|\+|-|\+1|-1|1)$`)
+
 	lineNr := 0
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
@@ -65,7 +117,7 @@ func (bf *Bedfile) readBed(file io.Reader) error {
 		lineText := scanner.Text()
 
 		// Handle headers
-		if headerPattern.MatchString(lineText) && len(bf.Lines) == 0 {
+		if headerPattern.MatchString(lineText) && expectedNrOfCols == 0 {
 			bf.Header = append(bf.Header, lineText)
 			continue
 		}
@@ -74,30 +126,31 @@ func (bf *Bedfile) readBed(file io.Reader) error {
 		l.Full = strings.Split(lineText, "\t")
 
 		// For the first non-header line save the number of columns
-		if lineNr == len(bf.Header)+1 && expectedNrOfCols == 0 {
+		if expectedNrOfCols == 0 {
 			expectedNrOfCols = len(l.Full)
 			if expectedNrOfCols < minNrCols {
-				return fmt.Errorf("less than %d columns on line %d: %s", minNrCols, lineNr, lineText)
+				return 0, fmt.Errorf("less than %d columns on line %d: %s", minNrCols, lineNr, lineText)
 			}
 		}
 		if len(l.Full) != expectedNrOfCols {
-			return fmt.Errorf("expected %d columns on line %d got %d: %s",
+			return 0, fmt.Errorf("expected %d columns on line %d got %d: %s",
 				expectedNrOfCols, lineNr, len(l.Full), lineText)
 		}
 
 		// Fill struct
+		l.Full[chrIdx] = bf.normaliseChr(l.Full[chrIdx])
 		l.Chr = l.Full[chrIdx]
 		l.Start, err = strconv.Atoi(l.Full[startIdx])
 		if err != nil {
-			return fmt.Errorf("non-int start position on line %d: %s", lineNr, l.Full[startIdx])
+			return 0, fmt.Errorf("non-int start position on line %d: %s", lineNr, l.Full[startIdx])
 		}
 		l.Stop, err = strconv.Atoi(l.Full[stopIdx])
 		if err != nil {
-			return fmt.Errorf("non-int stop position on line %d: %s", lineNr, l.Full[stopIdx])
+			return 0, fmt.Errorf("non-int stop position on line %d: %s", lineNr, l.Full[stopIdx])
 		}
 		// Verify start and stop
 		if l.Start > l.Stop {
-			return fmt.Errorf("stop is greater than start on line %d: %d > %d\n", lineNr, l.Start, l.Stop)
+			return 0, fmt.Errorf("stop is greater than start on line %d: %d > %d\n", lineNr, l.Start, l.Stop)
 		}
 		if l.Start == l.Stop {
 			fmt.Fprintf(os.Stderr, "warning: start and stop is equal on line %d: %d == %d\n", lineNr, l.Start, l.Stop)
@@ -105,23 +158,36 @@ func (bf *Bedfile) readBed(file io.Reader) error {
 		// Set strand and feature if selected
 		if bf.StrandCol > stopIdx {
 			if bf.StrandCol > len(l.Full)-1 {
-				return fmt.Errorf("given strand column, %d, is outside bed file (nr columns=%d)", bf.StrandCol+1, len(l.Full))
+				return 0, fmt.Errorf("given strand column, %d, is outside bed file (nr columns=%d)", bf.StrandCol+1, len(l.Full))
 			}
 			l.Strand = l.Full[bf.StrandCol]
 			// Verify strand format
 			if !strandPattern.MatchString(l.Strand) {
-				return fmt.Errorf("unexpected strand format on line %d: %s", lineNr, l.Strand)
+				return 0, fmt.Errorf("unexpected strand format on line %d: %s", lineNr, l.Strand)
 			}
 		}
 		if bf.FeatCol > stopIdx {
 			if bf.FeatCol > len(l.Full)-1 {
-				return fmt.Errorf("given strand column, %d, is outside bed file (nr columns=%d)", bf.FeatCol+1, len(l.Full))
+				return 0, fmt.Errorf("given strand column, %d, is outside bed file (nr columns=%d)", bf.FeatCol+1, len(l.Full))
 			}
 			l.Feat = l.Full[bf.FeatCol]
 		}
-		bf.Lines = append(bf.Lines, l)
+		if bf.PaddingCol > stopIdx {
+			if bf.PaddingCol > len(l.Full)-1 {
+				return 0, fmt.Errorf("given padding column, %d, is outside bed file (nr columns=%d)", bf.PaddingCol+1, len(l.Full))
+			}
+			// Verify padding format
+			if !emptyPadding(l.Full[bf.PaddingCol]) {
+				if _, _, err := parsePadding(l.Full[bf.PaddingCol]); err != nil {
+					return 0, fmt.Errorf("padding on line %d %w", lineNr, err)
+				}
+			}
+		}
+		if err := handleLine(l); err != nil {
+			return 0, err
+		}
 	}
-	return nil
+	return expectedNrOfCols, scanner.Err()
 }
 
 // Reading the fasta index file
@@ -157,8 +223,9 @@ func (bf *Bedfile) readFastaIdx(file io.Reader) error {
 		if err != nil {
 			return fmt.Errorf("non-int size for chr %s on line %d: %s", cols[chrFIdx], lineNr, cols[sizeFIdx])
 		}
-		chrLengthMap[cols[chrFIdx]] = size
-		chrOrder = append(chrOrder, cols[chrFIdx])
+		chr := bf.normaliseChr(cols[chrFIdx])
+		chrLengthMap[chr] = size
+		chrOrder = append(chrOrder, chr)
 	}
 	// Check that file is not empty
 	if lineNr == 0 {
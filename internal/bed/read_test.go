@@ -48,6 +48,38 @@ func TestReadBed(t *testing.T) {
 				},
 			},
 		},
+		{
+			testing: "normalised chromosome names",
+			bed: Bedfile{
+				Inputs:      []string{"test.bed"},
+				ChrPrefix:   StripCP,
+				ChrMT:       MTCM,
+				chrAliasMap: map[string]string{"NC_000002.12": "chr2"},
+			},
+			bedFileContent: "chr1\t10\t100\n" +
+				"NC_000002.12\t20\t200\n" +
+				"chrM\t30\t300\n",
+			expectedBed: Bedfile{
+				Inputs:      []string{"test.bed"},
+				ChrPrefix:   StripCP,
+				ChrMT:       MTCM,
+				chrAliasMap: map[string]string{"NC_000002.12": "chr2"},
+				Lines: []Line{
+					{
+						Chr: "1", Start: 10, Stop: 100,
+						Full: []string{"1", "10", "100"},
+					},
+					{
+						Chr: "2", Start: 20, Stop: 200,
+						Full: []string{"2", "20", "200"},
+					},
+					{
+						Chr: "MT", Start: 30, Stop: 300,
+						Full: []string{"MT", "30", "300"},
+					},
+				},
+			},
+		},
 		{
 			testing: "simple bed file, equal start and stop",
 			bed: Bedfile{
@@ -157,6 +189,34 @@ func TestReadBed(t *testing.T) {
 				},
 			},
 		},
+		{
+			testing: "bed file with padding",
+			bed: Bedfile{
+				Inputs:     []string{"test.bed"},
+				PaddingCol: 4 - 1,
+			},
+			bedFileContent: "1\t10\t100\t10\n" +
+				"2\t20\t200\t.\n" +
+				"3\t30\t300\t0.1x\n",
+			expectedBed: Bedfile{
+				Inputs:     []string{"test.bed"},
+				PaddingCol: 4 - 1,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 10, Stop: 100,
+						Full: []string{"1", "10", "100", "10"},
+					},
+					{
+						Chr: "2", Start: 20, Stop: 200,
+						Full: []string{"2", "20", "200", "."},
+					},
+					{
+						Chr: "3", Start: 30, Stop: 300,
+						Full: []string{"3", "30", "300", "0.1x"},
+					},
+				},
+			},
+		},
 		{
 			testing: "bed file already contains lines",
 			bed: Bedfile{
@@ -504,6 +564,27 @@ func TestReadBed(t *testing.T) {
 				"4\t40\t400\t1\tD\n",
 			shouldFail: true,
 		},
+		{
+			testing: "padding in incorrect format",
+			bed: Bedfile{
+				Inputs:     []string{"test.bed"},
+				PaddingCol: 4 - 1,
+			},
+			bedFileContent: "1\t10\t100\t10\n" +
+				"2\t20\t200\t.\n" +
+				"3\t30\t300\t0.1\n",
+			shouldFail: true,
+		},
+		{
+			testing: "padding col outside bed",
+			bed: Bedfile{
+				Inputs:     []string{"test.bed"},
+				PaddingCol: 5 - 1,
+			},
+			bedFileContent: "1\t10\t100\t10\n" +
+				"2\t20\t200\t.\n",
+			shouldFail: true,
+		},
 		{
 			testing: "bed file already contains lines, second file contains different number of columns",
 			bed: Bedfile{
@@ -656,6 +737,29 @@ func TestReadfastaIdx(t *testing.T) {
 				},
 			},
 		},
+		{
+			testing: "normalised chromosome names",
+			bed: Bedfile{
+				FastaIdx:  "test.fasta.fai",
+				SortType:  FidxST,
+				ChrPrefix: AddCP,
+			},
+			fastaIdxFileContent: "1\t249250621\t52\t60\t61\n" +
+				"chr2\t243199373\t253404903\t60\t61\n",
+			expectedBed: Bedfile{
+				FastaIdx:  "test.fasta.fai",
+				SortType:  FidxST,
+				ChrPrefix: AddCP,
+				chrLengthMap: map[string]int{
+					"chr1": 249250621,
+					"chr2": 243199373,
+				},
+				chrOrderMap: map[string]int{
+					"chr1": 1,
+					"chr2": 2,
+				},
+			},
+		},
 		{
 			testing: "only two columns",
 			bed: Bedfile{
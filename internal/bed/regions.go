@@ -0,0 +1,190 @@
+package bed
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// Lines of a bed file grouped by chromosome and sorted by start,
+// used to look up the lines close to a region
+type regionIndex struct {
+	lines map[string][]Line
+	// The largest stop among the lines up to and including
+	// the line with the same index
+	maxStops map[string][]int
+	// The lines sorted by stop
+	linesByStop map[string][]Line
+}
+
+// Create region index from lines
+func newRegionIndex(lines []Line) regionIndex {
+	idx := regionIndex{
+		lines:       map[string][]Line{},
+		maxStops:    map[string][]int{},
+		linesByStop: map[string][]Line{},
+	}
+	for _, l := range lines {
+		idx.lines[l.Chr] = append(idx.lines[l.Chr], l)
+	}
+	for chr, chrLines := range idx.lines {
+		slices.SortStableFunc(chrLines, func(a, b Line) int {
+			return cmp.Or(cmp.Compare(a.Start, b.Start), cmp.Compare(a.Stop, b.Stop))
+		})
+		maxStops := make([]int, len(chrLines))
+		for i, l := range chrLines {
+			maxStops[i] = l.Stop
+			if i > 0 && maxStops[i-1] > l.Stop {
+				maxStops[i] = maxStops[i-1]
+			}
+		}
+		idx.maxStops[chr] = maxStops
+		byStop := slices.Clone(chrLines)
+		slices.SortStableFunc(byStop, func(a, b Line) int {
+			return cmp.Or(cmp.Compare(a.Stop, b.Stop), cmp.Compare(a.Start, b.Start))
+		})
+		idx.linesByStop[chr] = byStop
+	}
+	return idx
+}
+
+// Lines on the chromosome that start at or before stop and stop
+// at or after start, ordered by start
+func (idx regionIndex) near(chr string, start, stop int) []Line {
+	var near []Line
+	lines := idx.lines[chr]
+	maxStops := idx.maxStops[chr]
+	// All lines before first stop before start, and all
+	// lines from last start after stop
+	first := sort.SearchInts(maxStops, start)
+	last := sort.Search(len(lines), func(i int) bool { return lines[i].Start > stop })
+	for i := first; i < last; i++ {
+		if lines[i].Stop >= start {
+			near = append(near, lines[i])
+		}
+	}
+	return near
+}
+
+// The lines on the chromosome with the smallest start that is at
+// or after pos
+func (idx regionIndex) nextRight(chr string, pos int) []Line {
+	lines := idx.lines[chr]
+	first := sort.Search(len(lines), func(i int) bool { return lines[i].Start >= pos })
+	last := first
+	for last < len(lines) && lines[last].Start == lines[first].Start {
+		last++
+	}
+	return lines[first:last]
+}
+
+// The lines on the chromosome with the largest stop that is at
+// or before pos
+func (idx regionIndex) nextLeft(chr string, pos int) []Line {
+	lines := idx.linesByStop[chr]
+	last := sort.Search(len(lines), func(i int) bool { return lines[i].Stop > pos })
+	first := last
+	for first > 0 && lines[first-1].Stop == lines[last-1].Stop {
+		first--
+	}
+	return lines[first:last]
+}
+
+// Lines in the region set that overlap the line
+func (bf Bedfile) overlapping(rs regionSet, l Line) []Line {
+	var overlapping []Line
+	for _, r := range rs.index.near(l.Chr, l.Start, l.Stop) {
+		if !rs.sameGroup(l, r) {
+			continue
+		}
+		if bf.overlapLength(l, r) > 0 {
+			overlapping = append(overlapping, r)
+		}
+	}
+	return overlapping
+}
+
+// Returns true if the overlap is at least the fraction of the line
+func (bf Bedfile) overlapFractionReached(l, r Line, fraction float64) bool {
+	if fraction == 0 {
+		return true
+	}
+	return float64(bf.overlapLength(l, r)) >= fraction*float64(bf.regionLength(l))
+}
+
+// Number of bases the lines overlap, zero or negative if they do
+// not overlap. With first base 1 the regions are treated as closed
+// intervals, otherwise as half-open intervals
+func (bf Bedfile) overlapLength(a, b Line) int {
+	return bf.regionLength(Line{Start: max(a.Start, b.Start), Stop: min(a.Stop, b.Stop)})
+}
+
+// Number of bases in the region
+func (bf Bedfile) regionLength(l Line) int {
+	return l.Stop - l.Start + bf.FirstBase
+}
+
+// Regions of a bed file used together with the input (e.g. the
+// file to intersect with). If strand or feat is true only regions
+// on the same strand or feature are compared with the input
+type regionSet struct {
+	strand bool
+	feat   bool
+	index  regionIndex
+}
+
+// Read the regions of a bed file, the strand and feature columns
+// are read from the same columns as in the input if selected
+func (bf Bedfile) readRegionSet(input string, strand, feat bool) (regionSet, error) {
+	rs := regionSet{strand: strand, feat: feat}
+	// Use the same chromosome names as the bed file
+	regions := Bedfile{
+		ChrPrefix:   bf.ChrPrefix,
+		ChrMT:       bf.ChrMT,
+		chrAliasMap: bf.chrAliasMap,
+	}
+	if strand {
+		regions.StrandCol = bf.StrandCol
+	}
+	if feat {
+		regions.FeatCol = bf.FeatCol
+	}
+	reader, bedFile, err := openBed(input)
+	if err != nil {
+		return rs, err
+	}
+	defer bedFile.Close()
+	if err := regions.readBed(reader); err != nil {
+		return rs, fmt.Errorf("can't read bed file %s: %q", input, err)
+	}
+	rs.index = newRegionIndex(regions.Lines)
+	return rs, nil
+}
+
+// Returns true if the line and region are on the same
+// strand and feature, if selected
+func (rs regionSet) sameGroup(l, r Line) bool {
+	return (!rs.strand || l.Strand == r.Strand) &&
+		(!rs.feat || l.Feat == r.Feat)
+}
+
+// Copy line with new start and stop
+func withCoordinates(l Line, start, stop int) Line {
+	full := slices.Clone(l.Full)
+	full[startIdx] = strconv.Itoa(start)
+	full[stopIdx] = strconv.Itoa(stop)
+	return Line{
+		Chr: l.Chr, Start: start, Stop: stop,
+		Strand: l.Strand, Feat: l.Feat,
+		Full: full,
+	}
+}
+
+// Copy line with the columns of other appended
+func withColumns(l, other Line) Line {
+	joined := l
+	joined.Full = slices.Concat(l.Full, other.Full)
+	return joined
+}
@@ -0,0 +1,147 @@
+package bed
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+var testRegions = []Line{
+	{
+		Chr: "1", Start: 20, Stop: 30,
+		Full: []string{"1", "20", "30"},
+	},
+	{
+		Chr: "1", Start: 1, Stop: 100,
+		Full: []string{"1", "1", "100"},
+	},
+	{
+		Chr: "1", Start: 5, Stop: 8,
+		Full: []string{"1", "5", "8"},
+	},
+	{
+		Chr: "2", Start: 5, Stop: 8,
+		Full: []string{"2", "5", "8"},
+	},
+}
+
+func TestRegionIndexNear(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing        string
+		chr            string
+		start          int
+		stop           int
+		expectedResult []Line
+	}
+	testCases := []testCase{
+		{
+			testing: "long region and overlapping region",
+			chr:     "1",
+			start:   10,
+			stop:    20,
+			expectedResult: []Line{
+				testRegions[1],
+				testRegions[0],
+			},
+		},
+		{
+			testing: "touching regions are included",
+			chr:     "1",
+			start:   8,
+			stop:    8,
+			expectedResult: []Line{
+				testRegions[1],
+				testRegions[2],
+			},
+		},
+		{
+			testing: "after all regions",
+			chr:     "1",
+			start:   101,
+			stop:    200,
+		},
+		{
+			testing: "chromosome not in index",
+			chr:     "3",
+			start:   1,
+			stop:    200,
+		},
+	}
+	idx := newRegionIndex(testRegions)
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			result := idx.near(tc.chr, tc.start, tc.stop)
+			if diff := deep.Equal(tc.expectedResult, result); diff != nil {
+				t.Error("expected VS received lines", diff)
+			}
+		})
+	}
+}
+
+func TestRegionIndexNextLeftAndRight(t *testing.T) {
+	t.Parallel()
+	idx := newRegionIndex(testRegions)
+	if diff := deep.Equal([]Line{testRegions[2]}, idx.nextLeft("1", 19)); diff != nil {
+		t.Error("expected VS received left lines", diff)
+	}
+	if left := idx.nextLeft("1", 7); len(left) != 0 {
+		t.Error("expected no left lines, got", left)
+	}
+	if diff := deep.Equal([]Line{testRegions[0]}, idx.nextRight("1", 9)); diff != nil {
+		t.Error("expected VS received right lines", diff)
+	}
+	if right := idx.nextRight("3", 0); len(right) != 0 {
+		t.Error("expected no right lines, got", right)
+	}
+}
+
+func TestOverlapLength(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing        string
+		bed            Bedfile
+		a              Line
+		b              Line
+		expectedResult int
+	}
+	testCases := []testCase{
+		{
+			testing:        "overlapping, first base 0",
+			a:              Line{Start: 1, Stop: 8},
+			b:              Line{Start: 5, Stop: 10},
+			expectedResult: 3,
+		},
+		{
+			testing:        "touching, first base 0",
+			a:              Line{Start: 1, Stop: 5},
+			b:              Line{Start: 5, Stop: 10},
+			expectedResult: 0,
+		},
+		{
+			testing:        "touching, first base 1",
+			bed:            Bedfile{FirstBase: 1},
+			a:              Line{Start: 1, Stop: 5},
+			b:              Line{Start: 5, Stop: 10},
+			expectedResult: 1,
+		},
+		{
+			testing:        "apart",
+			a:              Line{Start: 1, Stop: 5},
+			b:              Line{Start: 8, Stop: 10},
+			expectedResult: -3,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			result := tc.bed.overlapLength(tc.a, tc.b)
+			if tc.expectedResult != result {
+				t.Errorf("expected %d got %d", tc.expectedResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package bed
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Split the regions into --scatter files of roughly equal total
+// size (bp) and write them as numbered files in --scatter-dir.
+// The regions should be sorted before scattering
+func (bf *Bedfile) WriteScattered() error {
+	if err := os.MkdirAll(bf.ScatterDir, 0o755); err != nil {
+		return fmt.Errorf("cannot create scatter directory: %v", err)
+	}
+	for i, lines := range bf.scatterLines() {
+		chunk := *bf
+		chunk.Lines = lines
+		chunk.Output = scatterPath(bf.ScatterDir, i, bf.Scatter)
+		if err := chunk.Write(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Path of a scattered file, numbered from 1 and zero padded
+// so that the files are listed in order
+func scatterPath(dir string, i, n int) string {
+	width := len(strconv.Itoa(n))
+	return filepath.Join(dir, fmt.Sprintf("%0*d.bed", width, i+1))
+}
+
+// Divide the lines into --scatter chunks. The chunks are filled in
+// order, and a region (or chromosome if --scatter-keep-chr is set) is
+// put in the next chunk if less than half of it would fit in the
+// current one. The target size of the remaining chunks is updated for
+// each new chunk. With --scatter-split the regions are split at the
+// chunk borders instead
+func (bf Bedfile) scatterLines() [][]Line {
+	chunks := make([][]Line, bf.Scatter)
+	var total int
+	for _, l := range bf.Lines {
+		total += bf.regionLength(l)
+	}
+	if total == 0 {
+		return chunks
+	}
+	if bf.ScatterSplit {
+		return bf.scatterSplitLines(chunks, total)
+	}
+
+	// Group the lines into units that are kept together
+	var units [][]Line
+	for i, l := range bf.Lines {
+		if bf.ScatterKeepChr && i != 0 && l.Chr == bf.Lines[i-1].Chr {
+			units[len(units)-1] = append(units[len(units)-1], l)
+			continue
+		}
+		units = append(units, []Line{l})
+	}
+
+	remaining := total
+	target := total / bf.Scatter
+	var chunk, size int
+	for _, unit := range units {
+		var length int
+		for _, l := range unit {
+			length += bf.regionLength(l)
+		}
+		if size > 0 && chunk < bf.Scatter-1 && 2*size+length > 2*target {
+			chunk++
+			target = remaining / (bf.Scatter - chunk)
+			size = 0
+		}
+		chunks[chunk] = append(chunks[chunk], unit...)
+		size += length
+		remaining -= length
+	}
+	return chunks
+}
+
+// Divide the lines into chunks of equal total size,
+// splitting the regions at the chunk borders
+func (bf Bedfile) scatterSplitLines(chunks [][]Line, total int) [][]Line {
+	var cumulative int
+	chunk := 0
+	for _, l := range bf.Lines {
+		// Use half-open coordinates while splitting
+		start, stop := l.Start, l.Stop+bf.FirstBase
+		for start < stop {
+			border := (chunk + 1) * total / bf.Scatter
+			pieceStop := min(stop, start+border-cumulative)
+			if pieceStop > start {
+				chunks[chunk] = append(chunks[chunk], withCoordinates(l, start, pieceStop-bf.FirstBase))
+				cumulative += pieceStop - start
+				start = pieceStop
+			}
+			if cumulative == border && chunk < bf.Scatter-1 {
+				chunk++
+			}
+		}
+	}
+	return chunks
+}
@@ -0,0 +1,189 @@
+package bed
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestScatterLines(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing        string
+		bed            Bedfile
+		expectedChunks [][]Line
+	}
+	lines := []Line{
+		{
+			Chr: "1", Start: 0, Stop: 100,
+			Full: []string{"1", "0", "100"},
+		},
+		{
+			Chr: "1", Start: 200, Stop: 250,
+			Full: []string{"1", "200", "250"},
+		},
+		{
+			Chr: "2", Start: 0, Stop: 30,
+			Full: []string{"2", "0", "30"},
+		},
+		{
+			Chr: "2", Start: 50, Stop: 70,
+			Full: []string{"2", "50", "70"},
+		},
+		{
+			Chr: "3", Start: 0, Stop: 10,
+			Full: []string{"3", "0", "10"},
+		},
+	}
+	testCases := []testCase{
+		{
+			testing: "whole regions",
+			bed: Bedfile{
+				Scatter: 3,
+				Lines:   lines,
+			},
+			expectedChunks: [][]Line{
+				{lines[0]},
+				{lines[1]},
+				{lines[2], lines[3], lines[4]},
+			},
+		},
+		{
+			testing: "keep chromosomes together",
+			bed: Bedfile{
+				Scatter:        3,
+				ScatterKeepChr: true,
+				Lines:          lines,
+			},
+			expectedChunks: [][]Line{
+				{lines[0], lines[1]},
+				{lines[2], lines[3]},
+				{lines[4]},
+			},
+		},
+		{
+			testing: "split regions",
+			bed: Bedfile{
+				Scatter:      3,
+				ScatterSplit: true,
+				Lines:        lines,
+			},
+			expectedChunks: [][]Line{
+				{
+					{
+						Chr: "1", Start: 0, Stop: 70,
+						Full: []string{"1", "0", "70"},
+					},
+				},
+				{
+					{
+						Chr: "1", Start: 70, Stop: 100,
+						Full: []string{"1", "70", "100"},
+					},
+					{
+						Chr: "1", Start: 200, Stop: 240,
+						Full: []string{"1", "200", "240"},
+					},
+				},
+				{
+					{
+						Chr: "1", Start: 240, Stop: 250,
+						Full: []string{"1", "240", "250"},
+					},
+					lines[2], lines[3], lines[4],
+				},
+			},
+		},
+		{
+			testing: "split regions with first base 1",
+			bed: Bedfile{
+				FirstBase:    1,
+				Scatter:      2,
+				ScatterSplit: true,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 10,
+						Full: []string{"1", "1", "10"},
+					},
+				},
+			},
+			expectedChunks: [][]Line{
+				{
+					{
+						Chr: "1", Start: 1, Stop: 5,
+						Full: []string{"1", "1", "5"},
+					},
+				},
+				{
+					{
+						Chr: "1", Start: 6, Stop: 10,
+						Full: []string{"1", "6", "10"},
+					},
+				},
+			},
+		},
+		{
+			testing: "more chunks than regions",
+			bed: Bedfile{
+				Scatter: 3,
+				Lines:   lines[:1],
+			},
+			expectedChunks: [][]Line{
+				{lines[0]},
+				nil,
+				nil,
+			},
+		},
+		{
+			testing: "no regions",
+			bed: Bedfile{
+				Scatter: 2,
+			},
+			expectedChunks: [][]Line{nil, nil},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			chunks := tc.bed.scatterLines()
+			if diff := deep.Equal(tc.expectedChunks, chunks); diff != nil {
+				t.Error("expected VS received chunks", diff)
+			}
+		})
+	}
+}
+
+func TestScatterPath(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing      string
+		i            int
+		n            int
+		expectedPath string
+	}
+	testCases := []testCase{
+		{
+			testing:      "less than ten files",
+			i:            0,
+			n:            5,
+			expectedPath: "scatter/1.bed",
+		},
+		{
+			testing:      "more than ten files",
+			i:            2,
+			n:            12,
+			expectedPath: "scatter/03.bed",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			path := scatterPath("scatter", tc.i, tc.n)
+			if path != tc.expectedPath {
+				t.Errorf("expected %q, got %q", tc.expectedPath, path)
+			}
+		})
+	}
+}
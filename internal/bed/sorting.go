@@ -22,17 +22,42 @@ var humanChrOrder = []string{"1", "chr1", "2", "chr2", "3", "chr3", "4", "chr4",
 // Note: mergeSort() is missing from this list as it
 // is only intended for internal use
 func (bf *Bedfile) Sort() error {
+	sortedLines, err := bf.sortLines(bf.Lines)
+	if err != nil {
+		return err
+	}
+	bf.Lines = sortedLines
+	return nil
+}
+
+// Sort lines according to the sorting type
+func (bf Bedfile) sortLines(lines []Line) ([]Line, error) {
 	switch bf.SortType {
 	case LexST:
-		bf.Lines = lexicographicSort(bf.Lines)
+		return lexicographicSort(lines), nil
 	case NatST:
-		bf.Lines = naturalSort(bf.Lines)
+		return naturalSort(lines), nil
 	case CcsST, FidxST:
-		bf.Lines = customChrSort(bf.Lines, bf.chrOrderMap)
+		return customChrSort(lines, bf.chrOrderMap), nil
 	default:
-		return fmt.Errorf("unknown sorting type %s", bf.SortType)
+		return nil, fmt.Errorf("unknown sorting type %s", bf.SortType)
+	}
+}
+
+// Compare chromosomes according to the sorting type
+//
+//	-1 if a is less than b
+//	 0 if a equals b
+//	+1 if a is greater than b
+func (bf Bedfile) chrCompare(a, b string) int {
+	switch bf.SortType {
+	case NatST:
+		return naturalStringCompare(a, b)
+	case CcsST, FidxST:
+		return stringMapCompare(a, b, bf.chrOrderMap)
+	default:
+		return cmp.Compare(strings.ToLower(a), strings.ToLower(b))
 	}
-	return nil
 }
 
 // Lexicographic sorting
@@ -89,18 +114,22 @@ func customChrSort(lines []Line, orderMap map[string]int) []Line {
 // Sorting hierarchy: feat, chr, strand, start, stop
 // Chr sorting: 1 < 10 < 2
 func mergeSort(lines []Line) []Line {
-	slices.SortStableFunc(lines, func(a, b Line) int {
-		return cmp.Or(
-			cmp.Compare(a.Feat, b.Feat),
-			cmp.Compare(a.Chr, b.Chr),
-			cmp.Compare(a.Strand, b.Strand),
-			cmp.Compare(a.Start, b.Start),
-			cmp.Compare(a.Stop, b.Stop),
-		)
-	})
+	slices.SortStableFunc(lines, mergeCompare)
 	return lines
 }
 
+// Order in which lines are merged, by feature, chromosome,
+// strand, start and stop
+func mergeCompare(a, b Line) int {
+	return cmp.Or(
+		cmp.Compare(a.Feat, b.Feat),
+		cmp.Compare(a.Chr, b.Chr),
+		cmp.Compare(a.Strand, b.Strand),
+		cmp.Compare(a.Start, b.Start),
+		cmp.Compare(a.Stop, b.Stop),
+	)
+}
+
 // Natural comparison of strings
 //
 //	-1 if a is less than b
@@ -0,0 +1,245 @@
+package bed
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// State used when merging, padding and writing lines while reading
+// Only the merged regions that can still be extended by the next
+// line, and the merged regions waiting to be written, are kept
+type lineStreamer struct {
+	bf                *Bedfile
+	writer            *bufio.Writer
+	headerWritten     bool
+	previous          *Line
+	seenChrs          map[string]bool
+	open              []mergedRegion
+	closed            []Line
+	chrNotInLengthMap []string
+	clipped           []clippedLine
+	invalid           []string
+}
+
+// Merge, pad and write lines while reading them
+// The input has to be sorted by chromosome according to the sorting
+// type and by start position, so that only a small number of lines
+// has to be kept in memory
+func (bf *Bedfile) StreamLines() error {
+	if err := bf.openAndReadChrAliases(); err != nil {
+		return err
+	}
+	if err := bf.openAndReadFastaIdx(); err != nil {
+		return err
+	}
+	var readers []io.Reader
+	for _, input := range bf.Inputs {
+		reader, bedFile, err := openBed(input)
+		if err != nil {
+			return err
+		}
+		defer bedFile.Close()
+		readers = append(readers, reader)
+	}
+	writer, err := bf.createOutput()
+	if err != nil {
+		return err
+	}
+	if err := bf.stream(readers, writer); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// Merge, pad and write lines from the readers to the writer
+func (bf *Bedfile) stream(readers []io.Reader, writer io.Writer) error {
+	var err error
+	ls := lineStreamer{
+		bf:       bf,
+		writer:   bufio.NewWriter(writer),
+		seenChrs: map[string]bool{},
+	}
+	nrOfCols := 0
+	for i, reader := range readers {
+		nrOfCols, err = bf.scanBed(reader, nrOfCols, ls.add)
+		if err != nil {
+			return fmt.Errorf("can't read bed file %s: %q", bf.Inputs[i], err)
+		}
+	}
+	if err := ls.flush(); err != nil {
+		return err
+	}
+	if err := ls.writeHeader(); err != nil {
+		return err
+	}
+	// If we have been padding print padding warnings
+	if bf.PaddingSelected() {
+		bf.paddingWarnings(ls.chrNotInLengthMap)
+		if err := bf.reportClipping(ls.clipped); err != nil {
+			return err
+		}
+	}
+	if err := bf.reportInvalid(ls.invalid); err != nil {
+		return err
+	}
+	return ls.writer.Flush()
+}
+
+// Verify the order of the line, then pad, merge and write it
+func (ls *lineStreamer) add(l Line) error {
+	var err error
+	if err := ls.verifyOrder(l); err != nil {
+		return err
+	}
+	// Validate line, invalid lines are only kept when warning
+	if ls.bf.ValidateSelected() {
+		if reason := ls.bf.invalidReason(l); reason != "" {
+			ls.invalid = append(ls.invalid, fmt.Sprintf("%s: %s", coordinates(l), reason))
+			if ls.bf.Validate != WarnVT {
+				return nil
+			}
+		}
+	}
+	if ls.previous != nil && ls.previous.Chr != l.Chr {
+		if err := ls.flush(); err != nil {
+			return err
+		}
+	}
+	original := l
+	ls.previous = &original
+
+	// Pad line
+	if ls.bf.PaddingSelected() {
+		l, ls.chrNotInLengthMap, ls.clipped, err = ls.bf.padAndRecordClipping(l, ls.chrNotInLengthMap, ls.clipped)
+		if err != nil {
+			return err
+		}
+	}
+	if ls.bf.NoMerge {
+		if ls.bf.OriginalCoords {
+			l = withOriginalCoordinates(l, original)
+		}
+		return ls.writeLines([]Line{l})
+	}
+
+	// Close the merged regions that the line can not be merged into
+	var stillOpen []mergedRegion
+	merged := false
+	for _, o := range ls.open {
+		switch {
+		case ls.bf.isMergeable(o.Line, l):
+			stillOpen = append(stillOpen, ls.bf.mergeLine(o, l, original))
+			merged = true
+		case o.Stop+ls.bf.Overlap < l.Start-1:
+			finished, err := ls.bf.finishMergedRegion(o)
+			if err != nil {
+				return err
+			}
+			ls.closed = append(ls.closed, finished)
+		default:
+			stillOpen = append(stillOpen, o)
+		}
+	}
+	if !merged {
+		stillOpen = append(stillOpen, ls.bf.newMergedRegion(l, original))
+	}
+	ls.open = stillOpen
+	return ls.writeClosed()
+}
+
+// Verify that the line is sorted according to the sorting type
+func (ls *lineStreamer) verifyOrder(l Line) error {
+	if ls.previous == nil {
+		ls.seenChrs[l.Chr] = true
+		return ls.bf.verifyMergeCols(l)
+	}
+	if ls.previous.Chr != l.Chr {
+		if ls.seenChrs[l.Chr] || ls.bf.chrCompare(ls.previous.Chr, l.Chr) > 0 {
+			return fmt.Errorf("input is not sorted, chromosome %s found after chromosome %s (--sort-type=%s)",
+				l.Chr, ls.previous.Chr, ls.bf.SortType)
+		}
+		ls.seenChrs[l.Chr] = true
+		return nil
+	}
+	if ls.previous.Start > l.Start {
+		return fmt.Errorf("input is not sorted, start %d found after start %d on chromosome %s",
+			l.Start, ls.previous.Start, l.Chr)
+	}
+	return nil
+}
+
+// Write the closed regions that are guaranteed to be sorted before
+// the open ones. The stop of an open region can only grow, so a
+// closed region sorted before an open region will remain so
+func (ls *lineStreamer) writeClosed() error {
+	var ready, waiting []Line
+	for _, c := range ls.closed {
+		isReady := true
+		for _, o := range ls.open {
+			if cmp.Or(cmp.Compare(c.Start, o.Start), cmp.Compare(c.Stop, o.Stop)) >= 0 {
+				isReady = false
+				break
+			}
+		}
+		if isReady {
+			ready = append(ready, c)
+		} else {
+			waiting = append(waiting, c)
+		}
+	}
+	ls.closed = waiting
+	return ls.writeLines(ready)
+}
+
+// Write all remaining regions, used when all lines of a
+// chromosome have been read
+func (ls *lineStreamer) flush() error {
+	lines := ls.closed
+	for _, o := range ls.open {
+		finished, err := ls.bf.finishMergedRegion(o)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, finished)
+	}
+	ls.open = nil
+	ls.closed = nil
+	return ls.writeLines(lines)
+}
+
+// Sort and write lines
+func (ls *lineStreamer) writeLines(lines []Line) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	if err := ls.writeHeader(); err != nil {
+		return err
+	}
+	sortedLines, err := ls.bf.sortLines(lines)
+	if err != nil {
+		return err
+	}
+	for _, l := range sortedLines {
+		if _, err := fmt.Fprintf(ls.writer, "%s\n", strings.Join(l.Full, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write header before the first line
+func (ls *lineStreamer) writeHeader() error {
+	if ls.headerWritten {
+		return nil
+	}
+	ls.headerWritten = true
+	for _, h := range ls.bf.Header {
+		if _, err := fmt.Fprintf(ls.writer, "%s\n", h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package bed
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing        string
+		bed            Bedfile
+		bedFileContent []string
+		expectedOutput string
+		shouldFail     bool
+	}
+	padLeft, padRight := 100, 0
+	testCases := []testCase{
+		{
+			testing: "sorted bed file, merge chr only",
+			bed: Bedfile{
+				Inputs:   []string{"test.bed"},
+				SortType: LexST,
+			},
+			bedFileContent: []string{
+				"1\t1\t4\t1\tA\n" +
+					"1\t5\t8\t1\tA\n" +
+					"1\t5\t8\t-1\tA\n" +
+					"1\t5\t8\t1\tB\n" +
+					"1\t6\t8\t1\tA\n" +
+					"1\t20\t30\t1\tA\n" +
+					"2\t6\t8\t1\tA\n",
+			},
+			expectedOutput: "1\t1\t8\t1,-1\tA,B\n" +
+				"1\t20\t30\t1\tA\n" +
+				"2\t6\t8\t1\tA\n",
+		},
+		{
+			testing: "sorted bed file, merge chr, strand and feat",
+			bed: Bedfile{
+				Inputs:    []string{"test.bed"},
+				SortType:  LexST,
+				StrandCol: 4 - 1,
+				FeatCol:   5 - 1,
+			},
+			bedFileContent: []string{
+				"1\t1\t4\t1\tA\n" +
+					"1\t5\t8\t1\tA\n" +
+					"1\t5\t8\t-1\tA\n" +
+					"1\t5\t8\t1\tB\n" +
+					"1\t6\t8\t1\tA\n" +
+					"1\t20\t30\t1\tA\n" +
+					"2\t6\t8\t1\tA\n",
+			},
+			expectedOutput: "1\t1\t8\t1\tA\n" +
+				"1\t5\t8\t-1\tA\n" +
+				"1\t5\t8\t1\tB\n" +
+				"1\t20\t30\t1\tA\n" +
+				"2\t6\t8\t1\tA\n",
+		},
+		{
+			testing: "long region keeps later regions waiting",
+			bed: Bedfile{
+				Inputs:   []string{"test.bed"},
+				SortType: LexST,
+				FeatCol:  4 - 1,
+			},
+			bedFileContent: []string{
+				"1\t1\t100\tA\n" +
+					"1\t10\t20\tB\n" +
+					"1\t30\t40\tB\n" +
+					"1\t50\t60\tB\n",
+			},
+			expectedOutput: "1\t1\t100\tA\n" +
+				"1\t10\t20\tB\n" +
+				"1\t30\t40\tB\n" +
+				"1\t50\t60\tB\n",
+		},
+		{
+			testing: "sorted bed file with header, padding and overlap=-1",
+			bed: Bedfile{
+				Inputs:       []string{"test.bed"},
+				SortType:     LexST,
+				Padding:      5,
+				PaddingType:  SafePT,
+				FirstBase:    1,
+				Overlap:      -1,
+				chrLengthMap: testChrLengthMap,
+			},
+			bedFileContent: []string{
+				"track something\n" +
+					"1\t1\t4\n" +
+					"1\t5\t9\n" +
+					"1\t20\t30\n",
+			},
+			expectedOutput: "track something\n" +
+				"1\t1\t14\n" +
+				"1\t15\t35\n",
+		},
+		{
+			testing: "sorted bed file, strand and asymmetric padding",
+			bed: Bedfile{
+				Inputs:      []string{"test.bed"},
+				SortType:    LexST,
+				StrandCol:   4 - 1,
+				PadLeft:     &padLeft,
+				PadRight:    &padRight,
+				PaddingType: ForcePT,
+			},
+			bedFileContent: []string{
+				"1\t100\t200\t+\n" +
+					"1\t250\t260\t-\n" +
+					"1\t300\t310\t+\n",
+			},
+			expectedOutput: "1\t0\t310\t+\n" +
+				"1\t150\t260\t-\n",
+		},
+		{
+			testing: "sorted bed files, no merge",
+			bed: Bedfile{
+				Inputs:   []string{"test.bed", "test2.bed"},
+				SortType: NatST,
+				NoMerge:  true,
+			},
+			bedFileContent: []string{
+				"1\t1\t4\n" +
+					"1\t5\t9\n",
+				"2\t20\t30\n" +
+					"10\t20\t30\n",
+			},
+			expectedOutput: "1\t1\t4\n" +
+				"1\t5\t9\n" +
+				"2\t20\t30\n" +
+				"10\t20\t30\n",
+		},
+		{
+			testing: "sorted bed file, merge cols",
+			bed: Bedfile{
+				Inputs:    []string{"test.bed"},
+				SortType:  LexST,
+				MergeCols: []int{4 - 1},
+				MergeOps:  []string{MaxCO},
+			},
+			bedFileContent: []string{
+				"1\t1\t4\t3\tA\n" +
+					"1\t3\t8\t7\tA\n" +
+					"1\t20\t30\t1\tB\n",
+			},
+			expectedOutput: "1\t1\t8\t7\tA\n" +
+				"1\t20\t30\t1\tB\n",
+		},
+		{
+			testing: "sorted bed file, merge count and coords",
+			bed: Bedfile{
+				Inputs:      []string{"test.bed"},
+				SortType:    LexST,
+				MergeCount:  true,
+				MergeCoords: true,
+			},
+			bedFileContent: []string{
+				"1\t1\t4\n" +
+					"1\t3\t8\n" +
+					"1\t20\t30\n",
+			},
+			expectedOutput: "1\t1\t8\t2\t1:1-4,1:3-8\n" +
+				"1\t20\t30\t1\t1:20-30\n",
+		},
+		{
+			testing: "sorted bed file, original coords",
+			bed: Bedfile{
+				Inputs:         []string{"test.bed"},
+				SortType:       LexST,
+				OriginalCoords: true,
+			},
+			bedFileContent: []string{
+				"1\t1\t4\n" +
+					"1\t3\t8\n" +
+					"1\t20\t30\n",
+			},
+			expectedOutput: "1\t1\t8\t1\t8\t1:1-4,1:3-8\n" +
+				"1\t20\t30\t20\t30\t1:20-30\n",
+		},
+		{
+			testing: "sorted bed file, drop invalid regions",
+			bed: Bedfile{
+				Inputs:       []string{"test.bed"},
+				SortType:     LexST,
+				Validate:     DropVT,
+				chrLengthMap: map[string]int{"1": 100},
+			},
+			bedFileContent: []string{
+				"1\t1\t4\n" +
+					"1\t90\t110\n" +
+					"2\t5\t8\n",
+			},
+			expectedOutput: "1\t1\t4\n",
+		},
+		{
+			testing: "sorted bed file, fail on invalid regions",
+			bed: Bedfile{
+				Inputs:       []string{"test.bed"},
+				SortType:     LexST,
+				Validate:     FailVT,
+				chrLengthMap: map[string]int{"1": 100},
+			},
+			bedFileContent: []string{
+				"1\t1\t4\n" +
+					"2\t5\t8\n",
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "only header",
+			bed: Bedfile{
+				Inputs:   []string{"test.bed"},
+				SortType: LexST,
+			},
+			bedFileContent: []string{
+				"track something\n",
+			},
+			expectedOutput: "track something\n",
+		},
+		{
+			testing: "unsorted start",
+			bed: Bedfile{
+				Inputs:   []string{"test.bed"},
+				SortType: LexST,
+			},
+			bedFileContent: []string{
+				"1\t5\t9\n" +
+					"1\t1\t4\n",
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "unsorted chromosomes according to sort type",
+			bed: Bedfile{
+				Inputs:   []string{"test.bed"},
+				SortType: LexST,
+			},
+			bedFileContent: []string{
+				"2\t1\t4\n" +
+					"10\t1\t4\n",
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "chromosome not in one block",
+			bed: Bedfile{
+				Inputs:   []string{"test.bed", "test2.bed"},
+				SortType: LexST,
+			},
+			bedFileContent: []string{
+				"1\t1\t4\n" +
+					"2\t1\t4\n",
+				"1\t5\t9\n",
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			var readers []io.Reader
+			for _, content := range tc.bedFileContent {
+				readers = append(readers, strings.NewReader(content))
+			}
+			var output bytes.Buffer
+			err := tc.bed.stream(readers, &output)
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail {
+				if tc.expectedOutput != output.String() {
+					t.Error("expectedOutput vs output:\n",
+						tc.expectedOutput, "\n!=\n", output.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package bed
+
+// Remove the parts of the regions overlapping the regions in the
+// subtract file, regions are split if necessary
+func (bf *Bedfile) SubtractLines() error {
+	rs, err := bf.readRegionSet(bf.Subtract, bf.SubtractGrouped && bf.StrandCol != 0, bf.SubtractGrouped && bf.FeatCol != 0)
+	if err != nil {
+		return err
+	}
+
+	var subtracted []Line
+	for _, l := range bf.Lines {
+		var overlapping []Line
+		for _, r := range bf.overlapping(rs, l) {
+			if bf.overlapFractionReached(l, r, bf.SubtractFraction) {
+				overlapping = append(overlapping, r)
+			}
+		}
+		switch {
+		case len(overlapping) == 0:
+			subtracted = append(subtracted, l)
+		case !bf.SubtractWhole:
+			subtracted = append(subtracted, bf.subtractLine(l, overlapping)...)
+		}
+	}
+	bf.Lines = subtracted
+	return nil
+}
+
+// Split the line into the parts not covered by the overlapping
+// regions, the overlapping regions must be ordered by start
+func (bf Bedfile) subtractLine(l Line, overlapping []Line) []Line {
+	var parts []Line
+	start := l.Start
+	for _, r := range overlapping {
+		// With first base 1 the regions are closed intervals,
+		// so the part ends before the region starts
+		part := Line{Start: start, Stop: min(r.Start-bf.FirstBase, l.Stop)}
+		if bf.regionLength(part) > 0 {
+			parts = append(parts, withCoordinates(l, part.Start, part.Stop))
+		}
+		start = max(start, r.Stop+bf.FirstBase)
+	}
+	part := Line{Start: start, Stop: l.Stop}
+	if bf.regionLength(part) > 0 {
+		parts = append(parts, withCoordinates(l, part.Start, part.Stop))
+	}
+	return parts
+}
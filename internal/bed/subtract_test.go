@@ -0,0 +1,229 @@
+package bed
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+var testSubtractContent = "1\t3\t6\tx\n" +
+	"1\t25\t27\ty\n" +
+	"2\t1\t100\tz\n"
+
+var testSubtractLines = []Line{
+	{
+		Chr: "1", Start: 1, Stop: 8,
+		Full: []string{"1", "1", "8", "A"},
+	},
+	{
+		Chr: "1", Start: 20, Stop: 30,
+		Full: []string{"1", "20", "30", "B"},
+	},
+	{
+		Chr: "2", Start: 5, Stop: 8,
+		Full: []string{"2", "5", "8", "C"},
+	},
+	{
+		Chr: "3", Start: 5, Stop: 8,
+		Full: []string{"3", "5", "8", "D"},
+	},
+}
+
+func TestSubtractLines(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing         string
+		bed             Bedfile
+		subtractContent string
+		expectedLines   []Line
+		shouldFail      bool
+	}
+	testCases := []testCase{
+		{
+			testing:         "split regions",
+			bed:             Bedfile{Lines: testSubtractLines},
+			subtractContent: testSubtractContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 1, Stop: 3,
+					Full: []string{"1", "1", "3", "A"},
+				},
+				{
+					Chr: "1", Start: 6, Stop: 8,
+					Full: []string{"1", "6", "8", "A"},
+				},
+				{
+					Chr: "1", Start: 20, Stop: 25,
+					Full: []string{"1", "20", "25", "B"},
+				},
+				{
+					Chr: "1", Start: 27, Stop: 30,
+					Full: []string{"1", "27", "30", "B"},
+				},
+				testSubtractLines[3],
+			},
+		},
+		{
+			testing: "split regions, first base 1",
+			bed: Bedfile{
+				FirstBase: 1,
+				Lines:     testSubtractLines,
+			},
+			subtractContent: testSubtractContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 1, Stop: 2,
+					Full: []string{"1", "1", "2", "A"},
+				},
+				{
+					Chr: "1", Start: 7, Stop: 8,
+					Full: []string{"1", "7", "8", "A"},
+				},
+				{
+					Chr: "1", Start: 20, Stop: 24,
+					Full: []string{"1", "20", "24", "B"},
+				},
+				{
+					Chr: "1", Start: 28, Stop: 30,
+					Full: []string{"1", "28", "30", "B"},
+				},
+				testSubtractLines[3],
+			},
+		},
+		{
+			testing: "overlapping regions to subtract",
+			bed: Bedfile{
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 30,
+						Full: []string{"1", "1", "30"},
+					},
+				},
+			},
+			subtractContent: "1\t5\t20\n" +
+				"1\t8\t10\n" +
+				"1\t22\t40\n",
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 1, Stop: 5,
+					Full: []string{"1", "1", "5"},
+				},
+				{
+					Chr: "1", Start: 20, Stop: 22,
+					Full: []string{"1", "20", "22"},
+				},
+			},
+		},
+		{
+			testing: "subtract whole",
+			bed: Bedfile{
+				SubtractWhole: true,
+				Lines:         testSubtractLines,
+			},
+			subtractContent: testSubtractContent,
+			expectedLines: []Line{
+				testSubtractLines[3],
+			},
+		},
+		{
+			testing: "subtract whole and fraction",
+			bed: Bedfile{
+				SubtractWhole:    true,
+				SubtractFraction: 0.5,
+				Lines:            testSubtractLines,
+			},
+			subtractContent: testSubtractContent,
+			expectedLines: []Line{
+				testSubtractLines[0],
+				testSubtractLines[1],
+				testSubtractLines[3],
+			},
+		},
+		{
+			testing: "grouped by strand",
+			bed: Bedfile{
+				SubtractGrouped: true,
+				StrandCol:       4 - 1,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 8, Strand: "+",
+						Full: []string{"1", "1", "8", "+"},
+					},
+					{
+						Chr: "1", Start: 1, Stop: 8, Strand: "-",
+						Full: []string{"1", "1", "8", "-"},
+					},
+				},
+			},
+			subtractContent: "1\t3\t6\t-\n",
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 1, Stop: 8, Strand: "+",
+					Full: []string{"1", "1", "8", "+"},
+				},
+				{
+					Chr: "1", Start: 1, Stop: 3, Strand: "-",
+					Full: []string{"1", "1", "3", "-"},
+				},
+				{
+					Chr: "1", Start: 6, Stop: 8, Strand: "-",
+					Full: []string{"1", "6", "8", "-"},
+				},
+			},
+		},
+		{
+			testing: "not grouped by strand",
+			bed: Bedfile{
+				StrandCol: 4 - 1,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 1, Stop: 8, Strand: "+",
+						Full: []string{"1", "1", "8", "+"},
+					},
+				},
+			},
+			subtractContent: "1\t3\t6\n",
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 1, Stop: 3, Strand: "+",
+					Full: []string{"1", "1", "3", "+"},
+				},
+				{
+					Chr: "1", Start: 6, Stop: 8, Strand: "+",
+					Full: []string{"1", "6", "8", "+"},
+				},
+			},
+		},
+		{
+			testing: "grouped by feature, feature col outside subtract file",
+			bed: Bedfile{
+				SubtractGrouped: true,
+				FeatCol:         4 - 1,
+				Lines:           testSubtractLines,
+			},
+			subtractContent: "1\t3\t6\n",
+			shouldFail:      true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			tc.bed.Subtract = filepath.Join(t.TempDir(), "subtract.bed")
+			if err := os.WriteFile(tc.bed.Subtract, []byte(tc.subtractContent), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			err := tc.bed.SubtractLines()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail {
+				if diff := deep.Equal(tc.expectedLines, tc.bed.Lines); diff != nil {
+					t.Error("expected VS received lines", diff)
+				}
+			}
+		})
+	}
+}
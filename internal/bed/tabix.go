@@ -0,0 +1,382 @@
+package bed
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Index types
+var NoneIT = "none" // Do not create an index
+var TbiIT = "tbi"   // Tabix index, supports chromosomes up to 2^29 bp
+var CsiIT = "csi"   // Coordinate-sorted index, supports larger chromosomes
+
+// Tabix constants, see the tabix and CSI specifications
+const (
+	tabixMinShift   = 14
+	tabixDepth      = 5
+	tabixUcscFormat = 0x10000 // Zero-based, half-open coordinates
+	tabixGenericFmt = 0       // One-based, closed coordinates
+	tabixChrCol     = 1
+	tabixStartCol   = 2
+	tabixStopCol    = 3
+	tabixMetaChar   = '#'
+	tabixMaxTbiStop = 1 << 29
+)
+
+// Position of a line in the BGZF compressed output
+type indexRecord struct {
+	refIdx     int
+	start      int
+	stop       int
+	voffStart  uint64
+	voffFinish uint64
+}
+
+// Index of a BGZF compressed bed file
+type tabixIndex struct {
+	firstBase int
+	skip      int
+	names     []string
+	records   []indexRecord
+}
+
+// Chunk of the compressed file containing lines in a bin
+type indexChunk struct {
+	start uint64
+	stop  uint64
+}
+
+// Writer compressing lines with BGZF while recording the virtual
+// offsets of each line. The index is written to indexPath when
+// the writer is closed
+type indexWriter struct {
+	bgzf      *bgzfWriter
+	indexPath string
+	indexType string
+	index     tabixIndex
+	line      []byte
+	lineStart uint64
+	inHeader  bool
+}
+
+func newIndexWriter(bgzf *bgzfWriter, indexPath, indexType string, firstBase int) *indexWriter {
+	return &indexWriter{
+		bgzf:      bgzf,
+		indexPath: indexPath,
+		indexType: indexType,
+		index:     tabixIndex{firstBase: firstBase},
+		inHeader:  true,
+	}
+}
+
+// Write content to the BGZF writer and index each complete line
+func (iw *indexWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		segment := p
+		if i := bytes.IndexByte(p, '\n'); i >= 0 {
+			segment = p[:i+1]
+		}
+		if len(iw.line) == 0 {
+			iw.lineStart = iw.bgzf.virtualOffset()
+		}
+		n, err := iw.bgzf.Write(segment)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		iw.line = append(iw.line, segment...)
+		if segment[len(segment)-1] == '\n' {
+			if err := iw.indexLine(); err != nil {
+				return written, err
+			}
+		}
+		p = p[len(segment):]
+	}
+	return written, nil
+}
+
+// Add the current line to the index, headers at the top
+// of the file are skipped
+func (iw *indexWriter) indexLine() error {
+	lineText := strings.TrimRight(string(iw.line), "\r\n")
+	iw.line = iw.line[:0]
+	if iw.inHeader && headerPattern.MatchString(lineText) {
+		iw.index.skip++
+		return nil
+	}
+	iw.inHeader = false
+	cols := strings.Split(lineText, "\t")
+	if len(cols) <= stopIdx {
+		return fmt.Errorf("can not index line with less than 3 columns: %s", lineText)
+	}
+	start, err := strconv.Atoi(cols[startIdx])
+	if err != nil {
+		return fmt.Errorf("can not index line with non-int start position: %s", lineText)
+	}
+	stop, err := strconv.Atoi(cols[stopIdx])
+	if err != nil {
+		return fmt.Errorf("can not index line with non-int stop position: %s", lineText)
+	}
+	return iw.index.add(cols[chrIdx], start, stop, iw.lineStart, iw.bgzf.virtualOffset())
+}
+
+// Close the BGZF writer and write the index
+func (iw *indexWriter) Close() error {
+	if len(iw.line) > 0 {
+		if err := iw.indexLine(); err != nil {
+			return err
+		}
+	}
+	if err := iw.bgzf.Close(); err != nil {
+		return err
+	}
+	file, err := os.Create(iw.indexPath)
+	if err != nil {
+		return fmt.Errorf("cannot create index file: %v", err)
+	}
+	defer file.Close()
+	indexBgzf := newBgzfWriter(file)
+	if iw.indexType == CsiIT {
+		err = iw.index.writeCsi(indexBgzf)
+	} else {
+		err = iw.index.writeTbi(indexBgzf)
+	}
+	if err != nil {
+		return err
+	}
+	return indexBgzf.Close()
+}
+
+// Add line to index, lines must be sorted by chromosome and start
+func (idx *tabixIndex) add(chr string, lineStart, lineStop int, voffStart, voffFinish uint64) error {
+	// Convert to zero-based half-open coordinates
+	start := lineStart - idx.firstBase
+	stop := lineStop
+	if stop <= start {
+		stop = start + 1
+	}
+	refIdx := len(idx.names) - 1
+	if refIdx < 0 || idx.names[refIdx] != chr {
+		if stringInSlice(idx.names, chr) {
+			return fmt.Errorf("chromosome %s is not contiguous in the output, can not create index", chr)
+		}
+		idx.names = append(idx.names, chr)
+		refIdx++
+	} else if last := idx.records[len(idx.records)-1]; last.start > start {
+		return fmt.Errorf("output is not sorted by start position on chromosome %s, can not create index: %d > %d",
+			chr, last.start+idx.firstBase, lineStart)
+	}
+	idx.records = append(idx.records, indexRecord{
+		refIdx: refIdx, start: start, stop: stop,
+		voffStart: voffStart, voffFinish: voffFinish,
+	})
+	return nil
+}
+
+// Write index in the tabix (.tbi) format
+func (idx tabixIndex) writeTbi(w io.Writer) error {
+	var buf bytes.Buffer
+	buf.WriteString("TBI\x01")
+	writeInt32(&buf, len(idx.names))
+	idx.writeAux(&buf)
+	for refIdx := range idx.names {
+		records := idx.recordsOnRef(refIdx)
+		for _, r := range records {
+			if r.stop > tabixMaxTbiStop {
+				return fmt.Errorf("position %d on chromosome %s is too large for a tbi index, use csi instead",
+					r.stop, idx.names[refIdx])
+			}
+		}
+		bins, binOrder := binRecords(records, tabixMinShift, tabixDepth)
+		writeInt32(&buf, len(binOrder))
+		for _, bin := range binOrder {
+			writeUint32(&buf, bin)
+			writeChunks(&buf, bins[bin])
+		}
+		linear := linearIndex(records, tabixMinShift)
+		writeInt32(&buf, len(linear))
+		for _, voff := range linear {
+			writeUint64(&buf, voff)
+		}
+	}
+	// Number of unplaced lines
+	writeUint64(&buf, 0)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Write index in the coordinate-sorted index (.csi) format
+func (idx tabixIndex) writeCsi(w io.Writer) error {
+	// Find a depth large enough to cover the largest stop position
+	maxStop := 0
+	for _, r := range idx.records {
+		maxStop = max(maxStop, r.stop)
+	}
+	depth := 0
+	for size := 1 << tabixMinShift; maxStop > size; size <<= 3 {
+		depth++
+	}
+	depth = max(depth, tabixDepth)
+
+	var aux bytes.Buffer
+	idx.writeAux(&aux)
+
+	var buf bytes.Buffer
+	buf.WriteString("CSI\x01")
+	writeInt32(&buf, tabixMinShift)
+	writeInt32(&buf, depth)
+	writeInt32(&buf, aux.Len())
+	buf.Write(aux.Bytes())
+	writeInt32(&buf, len(idx.names))
+	for refIdx := range idx.names {
+		records := idx.recordsOnRef(refIdx)
+		bins, binOrder := binRecords(records, tabixMinShift, depth)
+		linear := linearIndex(records, tabixMinShift)
+		writeInt32(&buf, len(binOrder))
+		for _, bin := range binOrder {
+			writeUint32(&buf, bin)
+			// The offset of the first line overlapping the bin
+			window := binFirstPos(bin, depth) >> tabixMinShift
+			writeUint64(&buf, linear[min(window, len(linear)-1)])
+			writeChunks(&buf, bins[bin])
+		}
+	}
+	// Number of unplaced lines
+	writeUint64(&buf, 0)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Write the tabix header fields shared by the tbi and csi formats
+func (idx tabixIndex) writeAux(buf *bytes.Buffer) {
+	format := tabixUcscFormat
+	if idx.firstBase == 1 {
+		format = tabixGenericFmt
+	}
+	var names bytes.Buffer
+	for _, name := range idx.names {
+		names.WriteString(name)
+		names.WriteByte(0)
+	}
+	writeInt32(buf, format)
+	writeInt32(buf, tabixChrCol)
+	writeInt32(buf, tabixStartCol)
+	writeInt32(buf, tabixStopCol)
+	writeInt32(buf, tabixMetaChar)
+	writeInt32(buf, idx.skip)
+	writeInt32(buf, names.Len())
+	buf.Write(names.Bytes())
+}
+
+// Return the records on a reference
+func (idx tabixIndex) recordsOnRef(refIdx int) []indexRecord {
+	var records []indexRecord
+	for _, r := range idx.records {
+		if r.refIdx == refIdx {
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+// Group records into bins, merging chunks that are adjacent in the file
+func binRecords(records []indexRecord, minShift, depth int) (map[uint32][]indexChunk, []uint32) {
+	var binOrder []uint32
+	bins := map[uint32][]indexChunk{}
+	for _, r := range records {
+		bin := reg2bin(r.start, r.stop, minShift, depth)
+		chunks, ok := bins[bin]
+		if !ok {
+			binOrder = append(binOrder, bin)
+		}
+		if len(chunks) > 0 && chunks[len(chunks)-1].stop == r.voffStart {
+			chunks[len(chunks)-1].stop = r.voffFinish
+		} else {
+			chunks = append(chunks, indexChunk{start: r.voffStart, stop: r.voffFinish})
+		}
+		bins[bin] = chunks
+	}
+	return bins, binOrder
+}
+
+// Create the linear index containing the offset of the first
+// line overlapping each window of size 1<<minShift
+func linearIndex(records []indexRecord, minShift int) []uint64 {
+	var linear []uint64
+	var set []bool
+	for _, r := range records {
+		last := (r.stop - 1) >> minShift
+		for len(linear) <= last {
+			linear = append(linear, 0)
+			set = append(set, false)
+		}
+		for window := r.start >> minShift; window <= last; window++ {
+			if !set[window] {
+				linear[window] = r.voffStart
+				set[window] = true
+			}
+		}
+	}
+	// Fill the windows without lines
+	for i := range linear {
+		if set[i] {
+			continue
+		}
+		if i == 0 {
+			linear[i] = records[0].voffStart
+		} else {
+			linear[i] = linear[i-1]
+		}
+	}
+	return linear
+}
+
+// Calculate the bin of a zero-based half-open region
+func reg2bin(start, stop, minShift, depth int) uint32 {
+	stop--
+	shift := minShift
+	offset := ((1 << (depth * 3)) - 1) / 7
+	for level := depth; level > 0; level-- {
+		if start>>shift == stop>>shift {
+			return uint32(offset + start>>shift)
+		}
+		shift += 3
+		offset -= 1 << ((level - 1) * 3)
+	}
+	return 0
+}
+
+// Calculate the first position covered by a bin
+func binFirstPos(bin uint32, depth int) int {
+	level, offset := 0, 0
+	for b := int(bin); level < depth && b >= offset+(1<<(level*3)); level++ {
+		offset += 1 << (level * 3)
+	}
+	return (int(bin) - offset) << ((depth-level)*3 + tabixMinShift)
+}
+
+func writeChunks(buf *bytes.Buffer, chunks []indexChunk) {
+	writeInt32(buf, len(chunks))
+	for _, c := range chunks {
+		writeUint64(buf, c.start)
+		writeUint64(buf, c.stop)
+	}
+}
+
+func writeInt32(buf *bytes.Buffer, v int) {
+	_ = binary.Write(buf, binary.LittleEndian, int32(v))
+}
+
+func writeUint32(buf *bytes.Buffer, v uint32) {
+	_ = binary.Write(buf, binary.LittleEndian, v)
+}
+
+func writeUint64(buf *bytes.Buffer, v uint64) {
+	_ = binary.Write(buf, binary.LittleEndian, v)
+}
@@ -0,0 +1,326 @@
+package bed
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestTabixIndexAdd(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing       string
+		firstBase     int
+		lines         []Line
+		expectedIndex tabixIndex
+		shouldFail    bool
+	}
+	testCases := []testCase{
+		{
+			testing: "sorted lines",
+			lines: []Line{
+				{Chr: "1", Start: 10, Stop: 100},
+				{Chr: "1", Start: 20, Stop: 200},
+				{Chr: "2", Start: 5, Stop: 5},
+			},
+			expectedIndex: tabixIndex{
+				names: []string{"1", "2"},
+				records: []indexRecord{
+					{refIdx: 0, start: 10, stop: 100, voffStart: 0, voffFinish: 10},
+					{refIdx: 0, start: 20, stop: 200, voffStart: 10, voffFinish: 20},
+					{refIdx: 1, start: 5, stop: 6, voffStart: 20, voffFinish: 30},
+				},
+			},
+		},
+		{
+			testing:   "sorted lines, first base 1",
+			firstBase: 1,
+			lines: []Line{
+				{Chr: "1", Start: 10, Stop: 100},
+			},
+			expectedIndex: tabixIndex{
+				firstBase: 1,
+				names:     []string{"1"},
+				records: []indexRecord{
+					{refIdx: 0, start: 9, stop: 100, voffStart: 0, voffFinish: 10},
+				},
+			},
+		},
+		{
+			testing: "lines not sorted by start",
+			lines: []Line{
+				{Chr: "1", Start: 20, Stop: 200},
+				{Chr: "1", Start: 10, Stop: 100},
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "chromosomes not contiguous",
+			lines: []Line{
+				{Chr: "1", Start: 10, Stop: 100},
+				{Chr: "2", Start: 10, Stop: 100},
+				{Chr: "1", Start: 20, Stop: 200},
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			var err error
+			idx := tabixIndex{firstBase: tc.firstBase}
+			for i, l := range tc.lines {
+				if err = idx.add(l.Chr, l.Start, l.Stop, uint64(i*10), uint64(i*10+10)); err != nil {
+					break
+				}
+			}
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail {
+				if diff := deep.Equal(tc.expectedIndex, idx); diff != nil {
+					t.Error("expected VS received index", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestIndexWriter(t *testing.T) {
+	t.Parallel()
+	var compressed bytes.Buffer
+	iw := newIndexWriter(newBgzfWriter(&compressed), "test.bed.gz.tbi", TbiIT, 0)
+	// Write content in chunks that are not aligned with the lines
+	content := "track something\n#something\n1\t10\t100\n1\t20\t200\n2\t5\t50\n"
+	for _, chunk := range []string{content[:20], content[20:35], content[35:]} {
+		if _, err := iw.Write([]byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectedIndex := tabixIndex{
+		skip:  2,
+		names: []string{"1", "2"},
+		records: []indexRecord{
+			{refIdx: 0, start: 10, stop: 100, voffStart: 27, voffFinish: 36},
+			{refIdx: 0, start: 20, stop: 200, voffStart: 36, voffFinish: 45},
+			{refIdx: 1, start: 5, stop: 50, voffStart: 45, voffFinish: 52},
+		},
+	}
+	if diff := deep.Equal(expectedIndex, iw.index); diff != nil {
+		t.Error("expected VS received index", diff)
+	}
+}
+
+func TestWriteTbi(t *testing.T) {
+	t.Parallel()
+	idx := tabixIndex{
+		skip:  2,
+		names: []string{"1", "2"},
+		records: []indexRecord{
+			{refIdx: 0, start: 10, stop: 100, voffStart: 0, voffFinish: 10},
+			{refIdx: 0, start: 20, stop: 200, voffStart: 10, voffFinish: 20},
+			{refIdx: 1, start: 5, stop: 40000, voffStart: 20, voffFinish: 30},
+		},
+	}
+	var buf bytes.Buffer
+	if err := idx.writeTbi(&buf); err != nil {
+		t.Fatal(err)
+	}
+	// Header and first chromosome containing one bin
+	// with one chunk and one linear index window
+	expectedFields := []any{
+		[4]byte{'T', 'B', 'I', 1},
+		int32(2),               // n_ref
+		int32(tabixUcscFormat), // format
+		int32(1),               // col_seq
+		int32(2),               // col_beg
+		int32(3),               // col_end
+		int32('#'),             // meta
+		int32(2),               // skip
+		int32(4),               // l_nm
+		[4]byte{'1', 0, '2', 0},
+		int32(1),     // n_bin
+		uint32(4681), // bin
+		int32(1),     // n_chunk
+		uint64(0),    // cnk_beg
+		uint64(20),   // cnk_end
+		int32(1),     // n_intv
+		uint64(0),    // ioff
+	}
+	var expected bytes.Buffer
+	for _, field := range expectedFields {
+		if err := binary.Write(&expected, binary.LittleEndian, field); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bytes.HasPrefix(buf.Bytes(), expected.Bytes()) {
+		t.Error("expected VS received index content\n",
+			expected.Bytes(), "\n!=\n", buf.Bytes()[:min(buf.Len(), expected.Len())])
+	}
+}
+
+func TestWriteCsi(t *testing.T) {
+	t.Parallel()
+	idx := tabixIndex{
+		skip:  2,
+		names: []string{"1", "2"},
+		records: []indexRecord{
+			{refIdx: 0, start: 10, stop: 100, voffStart: 0, voffFinish: 10},
+			{refIdx: 0, start: 20000, stop: 20100, voffStart: 10, voffFinish: 20},
+			{refIdx: 1, start: 5, stop: 40000, voffStart: 20, voffFinish: 30},
+		},
+	}
+	var buf bytes.Buffer
+	if err := idx.writeCsi(&buf); err != nil {
+		t.Fatal(err)
+	}
+	// Header, followed by the first chromosome with two bins on
+	// the lowest level and the second chromosome with one bin on
+	// the level above, each bin with its loffset and one chunk
+	expectedFields := []any{
+		[4]byte{'C', 'S', 'I', 1},
+		int32(tabixMinShift),   // min_shift
+		int32(tabixDepth),      // depth
+		int32(32),              // l_aux
+		int32(tabixUcscFormat), // format
+		int32(1),               // col_seq
+		int32(2),               // col_beg
+		int32(3),               // col_end
+		int32('#'),             // meta
+		int32(2),               // skip
+		int32(4),               // l_nm
+		[4]byte{'1', 0, '2', 0},
+		int32(2),     // n_ref
+		int32(2),     // n_bin
+		uint32(4681), // bin
+		uint64(0),    // loffset
+		int32(1),     // n_chunk
+		uint64(0),    // cnk_beg
+		uint64(10),   // cnk_end
+		uint32(4682), // bin
+		uint64(10),   // loffset
+		int32(1),     // n_chunk
+		uint64(10),   // cnk_beg
+		uint64(20),   // cnk_end
+		int32(1),     // n_bin
+		uint32(585),  // bin
+		uint64(20),   // loffset
+		int32(1),     // n_chunk
+		uint64(20),   // cnk_beg
+		uint64(30),   // cnk_end
+		uint64(0),    // n_no_coor
+	}
+	var expected bytes.Buffer
+	for _, field := range expectedFields {
+		if err := binary.Write(&expected, binary.LittleEndian, field); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bytes.Equal(buf.Bytes(), expected.Bytes()) {
+		t.Error("expected VS received index content\n",
+			expected.Bytes(), "\n!=\n", buf.Bytes())
+	}
+
+	// The depth is increased for chromosomes larger than 512 Mbp
+	large := tabixIndex{
+		names: []string{"1"},
+		records: []indexRecord{
+			{refIdx: 0, start: 1 << 29, stop: 1 << 30, voffStart: 0, voffFinish: 10},
+		},
+	}
+	buf.Reset()
+	if err := large.writeCsi(&buf); err != nil {
+		t.Fatal(err)
+	}
+	expected.Reset()
+	for _, field := range []any{[4]byte{'C', 'S', 'I', 1}, int32(tabixMinShift), int32(6)} {
+		if err := binary.Write(&expected, binary.LittleEndian, field); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !bytes.HasPrefix(buf.Bytes(), expected.Bytes()) {
+		t.Error("expected VS received index header\n",
+			expected.Bytes(), "\n!=\n", buf.Bytes()[:min(buf.Len(), expected.Len())])
+	}
+}
+
+func TestLinearIndex(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing        string
+		records        []indexRecord
+		expectedLinear []uint64
+	}
+	testCases := []testCase{
+		{
+			testing: "one record in first window",
+			records: []indexRecord{
+				{start: 10, stop: 100, voffStart: 5},
+			},
+			expectedLinear: []uint64{5},
+		},
+		{
+			testing: "records spanning several windows",
+			records: []indexRecord{
+				{start: 10, stop: 100, voffStart: 5},
+				{start: 16384, stop: 3 * 16384, voffStart: 10},
+				{start: 2 * 16384, stop: 2*16384 + 1, voffStart: 20},
+			},
+			expectedLinear: []uint64{5, 10, 10},
+		},
+		{
+			testing: "empty windows are filled",
+			records: []indexRecord{
+				{start: 2 * 16384, stop: 2*16384 + 1, voffStart: 5},
+				{start: 4 * 16384, stop: 4*16384 + 1, voffStart: 10},
+			},
+			expectedLinear: []uint64{5, 5, 5, 5, 10},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			linear := linearIndex(tc.records, tabixMinShift)
+			if diff := deep.Equal(tc.expectedLinear, linear); diff != nil {
+				t.Error("expected VS received linear index", diff)
+			}
+		})
+	}
+}
+
+func TestReg2bin(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		start       int
+		stop        int
+		depth       int
+		expectedBin uint32
+	}
+	testCases := []testCase{
+		{start: 0, stop: 1, depth: 5, expectedBin: 4681},
+		{start: 16384, stop: 16385, depth: 5, expectedBin: 4682},
+		{start: 0, stop: 16385, depth: 5, expectedBin: 585},
+		{start: 0, stop: 1 << 29, depth: 5, expectedBin: 0},
+		{start: 0, stop: 1, depth: 6, expectedBin: 37449},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		description := fmt.Sprintf("start=%d stop=%d depth=%d", tc.start, tc.stop, tc.depth)
+		t.Run(description, func(t *testing.T) {
+			t.Parallel()
+			bin := reg2bin(tc.start, tc.stop, tabixMinShift, tc.depth)
+			if tc.expectedBin != bin {
+				t.Errorf("expected bin %d got %d", tc.expectedBin, bin)
+			}
+			// The first position of the bin should be in the region
+			if firstPos := binFirstPos(bin, tc.depth); firstPos > tc.start {
+				t.Errorf("first position of bin %d is after start: %d > %d", bin, firstPos, tc.start)
+			}
+		})
+	}
+}
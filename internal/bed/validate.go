@@ -0,0 +1,68 @@
+package bed
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Validation types
+var NoneVT = "none" // Do not validate the regions
+var FailVT = "fail" // Fail if there are invalid regions
+var WarnVT = "warn" // Warn about invalid regions, but keep them
+var DropVT = "drop" // Remove invalid regions with a warning
+
+// Returns true if validation is selected
+func (bf Bedfile) ValidateSelected() bool {
+	return bf.Validate != "" && bf.Validate != NoneVT
+}
+
+// Validate the regions against the fasta index file,
+// all invalid regions are reported
+func (bf *Bedfile) ValidateLines() error {
+	var valid []Line
+	var invalid []string
+	for _, l := range bf.Lines {
+		if reason := bf.invalidReason(l); reason != "" {
+			invalid = append(invalid, fmt.Sprintf("%s: %s", coordinates(l), reason))
+			if bf.Validate == DropVT {
+				continue
+			}
+		}
+		valid = append(valid, l)
+	}
+	bf.Lines = valid
+	return bf.reportInvalid(invalid)
+}
+
+// Returns the reason the line is not valid according to the
+// fasta index file, or an empty string if it is valid
+func (bf Bedfile) invalidReason(l Line) string {
+	length, ok := bf.chrLengthMap[l.Chr]
+	switch {
+	case !ok:
+		return "chromosome not in fasta index file"
+	case l.Start < bf.FirstBase:
+		return fmt.Sprintf("start is before the first base of the chromosome (%d)", bf.FirstBase)
+	case l.Stop > length:
+		return fmt.Sprintf("stop is beyond the end of the chromosome (%d)", length)
+	}
+	return ""
+}
+
+// Fail or warn about the invalid regions depending on the validation type
+func (bf Bedfile) reportInvalid(invalid []string) error {
+	if len(invalid) == 0 {
+		return nil
+	}
+	list := strings.Join(invalid, "\n")
+	switch bf.Validate {
+	case FailVT:
+		return fmt.Errorf("%d regions are not valid according to the fasta index file %s:\n%s", len(invalid), bf.FastaIdx, list)
+	case WarnVT:
+		fmt.Fprintf(os.Stderr, "warning: %d regions are not valid according to the fasta index file %s:\n%s\n", len(invalid), bf.FastaIdx, list)
+	case DropVT:
+		fmt.Fprintf(os.Stderr, "warning: %d regions were removed as they are not valid according to the fasta index file %s:\n%s\n", len(invalid), bf.FastaIdx, list)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package bed
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestValidateLines(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing       string
+		bed           Bedfile
+		expectedLines []Line
+		shouldFail    bool
+	}
+	lines := []Line{
+		{
+			Chr: "1", Start: 0, Stop: 100,
+			Full: []string{"1", "0", "100"},
+		},
+		{
+			Chr: "1", Start: 90, Stop: 110,
+			Full: []string{"1", "90", "110"},
+		},
+		{
+			Chr: "2", Start: 5, Stop: 8,
+			Full: []string{"2", "5", "8"},
+		},
+	}
+	testCases := []testCase{
+		{
+			testing: "valid regions",
+			bed: Bedfile{
+				Validate:     FailVT,
+				chrLengthMap: map[string]int{"1": 100},
+				Lines:        lines[:1],
+			},
+			expectedLines: lines[:1],
+		},
+		{
+			testing: "fail on invalid regions",
+			bed: Bedfile{
+				Validate:     FailVT,
+				chrLengthMap: map[string]int{"1": 100},
+				Lines:        lines,
+			},
+			shouldFail: true,
+		},
+		{
+			testing: "warn about invalid regions",
+			bed: Bedfile{
+				Validate:     WarnVT,
+				chrLengthMap: map[string]int{"1": 100},
+				Lines:        lines,
+			},
+			expectedLines: lines,
+		},
+		{
+			testing: "drop invalid regions",
+			bed: Bedfile{
+				Validate:     DropVT,
+				chrLengthMap: map[string]int{"1": 100},
+				Lines:        lines,
+			},
+			expectedLines: lines[:1],
+		},
+		{
+			testing: "start before first base",
+			bed: Bedfile{
+				Validate:     FailVT,
+				FirstBase:    1,
+				chrLengthMap: map[string]int{"1": 100},
+				Lines:        lines[:1],
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			err := tc.bed.ValidateLines()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail {
+				if diff := deep.Equal(tc.expectedLines, tc.bed.Lines); diff != nil {
+					t.Error("expected VS received lines", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidReason(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing        string
+		bed            Bedfile
+		line           Line
+		expectedReason string
+	}
+	chrLengthMap := map[string]int{"1": 100}
+	testCases := []testCase{
+		{
+			testing:        "valid region",
+			bed:            Bedfile{chrLengthMap: chrLengthMap},
+			line:           Line{Chr: "1", Start: 0, Stop: 100},
+			expectedReason: "",
+		},
+		{
+			testing:        "valid region with first base 1",
+			bed:            Bedfile{FirstBase: 1, chrLengthMap: chrLengthMap},
+			line:           Line{Chr: "1", Start: 1, Stop: 100},
+			expectedReason: "",
+		},
+		{
+			testing:        "chromosome not in fasta index file",
+			bed:            Bedfile{chrLengthMap: chrLengthMap},
+			line:           Line{Chr: "2", Start: 0, Stop: 10},
+			expectedReason: "chromosome not in fasta index file",
+		},
+		{
+			testing:        "start before first base",
+			bed:            Bedfile{FirstBase: 1, chrLengthMap: chrLengthMap},
+			line:           Line{Chr: "1", Start: 0, Stop: 10},
+			expectedReason: "start is before the first base of the chromosome (1)",
+		},
+		{
+			testing:        "negative start",
+			bed:            Bedfile{chrLengthMap: chrLengthMap},
+			line:           Line{Chr: "1", Start: -5, Stop: 10},
+			expectedReason: "start is before the first base of the chromosome (0)",
+		},
+		{
+			testing:        "stop beyond chromosome",
+			bed:            Bedfile{chrLengthMap: chrLengthMap},
+			line:           Line{Chr: "1", Start: 90, Stop: 101},
+			expectedReason: "stop is beyond the end of the chromosome (100)",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			reason := tc.bed.invalidReason(tc.line)
+			if reason != tc.expectedReason {
+				t.Errorf("expected %q, got %q", tc.expectedReason, reason)
+			}
+		})
+	}
+}
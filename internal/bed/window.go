@@ -0,0 +1,41 @@
+package bed
+
+// Report the regions in the window file that are within the window
+// around each region, as the region followed by the region in the
+// window file
+func (bf *Bedfile) WindowLines() error {
+	rs, err := bf.readRegionSet(bf.Window, false, false)
+	if err != nil {
+		return err
+	}
+
+	var windowed []Line
+	for _, l := range bf.Lines {
+		left, right := bf.windowLeftAndRight(l)
+		window, _, err := bf.padLineWith(l, left, right)
+		if err != nil {
+			return err
+		}
+		for _, r := range bf.overlapping(rs, window) {
+			windowed = append(windowed, withColumns(l, r))
+		}
+	}
+	bf.Lines = windowed
+	return nil
+}
+
+// Size of the window to the left and right of the line. If the
+// window is strand aware left is upstream and right is downstream
+func (bf Bedfile) windowLeftAndRight(l Line) (int, int) {
+	left, right := bf.WindowSize, bf.WindowSize
+	if bf.WindowLeft != nil {
+		left = *bf.WindowLeft
+	}
+	if bf.WindowRight != nil {
+		right = *bf.WindowRight
+	}
+	if bf.WindowStrand && isMinusStrand(l.Strand) {
+		return right, left
+	}
+	return left, right
+}
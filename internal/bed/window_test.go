@@ -0,0 +1,150 @@
+package bed
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+var testWindowContent = "1\t5\t8\tx\n" +
+	"1\t20\t25\ty\n" +
+	"1\t45\t47\tw\n"
+
+var testWindowLines = []Line{
+	{
+		Chr: "1", Start: 10, Stop: 20, Strand: "+",
+		Full: []string{"1", "10", "20", "+"},
+	},
+	{
+		Chr: "1", Start: 30, Stop: 40, Strand: "-",
+		Full: []string{"1", "30", "40", "-"},
+	},
+}
+
+func TestWindowLines(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing       string
+		bed           Bedfile
+		windowContent string
+		expectedLines []Line
+		shouldFail    bool
+	}
+	left := 0
+	right := 6
+	testCases := []testCase{
+		{
+			testing: "symmetric window",
+			bed: Bedfile{
+				WindowSize: 5,
+				Lines:      testWindowLines,
+			},
+			windowContent: testWindowContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 10, Stop: 20, Strand: "+",
+					Full: []string{"1", "10", "20", "+", "1", "5", "8", "x"},
+				},
+				{
+					Chr: "1", Start: 10, Stop: 20, Strand: "+",
+					Full: []string{"1", "10", "20", "+", "1", "20", "25", "y"},
+				},
+			},
+		},
+		{
+			testing: "asymmetric window",
+			bed: Bedfile{
+				WindowSize:  5,
+				WindowLeft:  &left,
+				WindowRight: &right,
+				Lines:       testWindowLines,
+			},
+			windowContent: testWindowContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 10, Stop: 20, Strand: "+",
+					Full: []string{"1", "10", "20", "+", "1", "20", "25", "y"},
+				},
+				{
+					Chr: "1", Start: 30, Stop: 40, Strand: "-",
+					Full: []string{"1", "30", "40", "-", "1", "45", "47", "w"},
+				},
+			},
+		},
+		{
+			testing: "asymmetric strand aware window",
+			bed: Bedfile{
+				WindowSize:   5,
+				WindowLeft:   &left,
+				WindowRight:  &right,
+				WindowStrand: true,
+				StrandCol:    4 - 1,
+				Lines:        testWindowLines,
+			},
+			windowContent: testWindowContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 10, Stop: 20, Strand: "+",
+					Full: []string{"1", "10", "20", "+", "1", "20", "25", "y"},
+				},
+				{
+					Chr: "1", Start: 30, Stop: 40, Strand: "-",
+					Full: []string{"1", "30", "40", "-", "1", "20", "25", "y"},
+				},
+			},
+		},
+		{
+			testing: "window clamped at chromosome start",
+			bed: Bedfile{
+				WindowSize: 20,
+				Lines: []Line{
+					{
+						Chr: "1", Start: 10, Stop: 11,
+						Full: []string{"1", "10", "11"},
+					},
+				},
+			},
+			windowContent: testWindowContent,
+			expectedLines: []Line{
+				{
+					Chr: "1", Start: 10, Stop: 11,
+					Full: []string{"1", "10", "11", "1", "5", "8", "x"},
+				},
+				{
+					Chr: "1", Start: 10, Stop: 11,
+					Full: []string{"1", "10", "11", "1", "20", "25", "y"},
+				},
+			},
+		},
+		{
+			testing: "window file does not exist",
+			bed: Bedfile{
+				Lines: testWindowLines,
+			},
+			shouldFail: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			tc.bed.Window = filepath.Join(t.TempDir(), "window.bed")
+			if tc.windowContent != "" {
+				if err := os.WriteFile(tc.bed.Window, []byte(tc.windowContent), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := tc.bed.WindowLines()
+			if (!tc.shouldFail && err != nil) || (tc.shouldFail && err == nil) {
+				t.Fatalf("shouldFail is %t, but err is %q", tc.shouldFail, err)
+			}
+			if !tc.shouldFail {
+				if diff := deep.Equal(tc.expectedLines, tc.bed.Lines); diff != nil {
+					t.Error("expected VS received lines", diff)
+				}
+			}
+		})
+	}
+}
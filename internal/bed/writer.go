@@ -1,26 +1,108 @@
 package bed
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 )
 
+// Output destination that closes all its layers
+// (e.g. compression and file) in order
+type output struct {
+	io.Writer
+	closers []io.Closer
+}
+
+func (o output) Close() error {
+	var errs []error
+	for _, c := range o.closers {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
+
 // Writing bed file or standard output
+// If the output file ends with .gz the output is BGZF compressed
+// and can be indexed
 func (bf *Bedfile) Write() error {
-	// If output is not set write to Stdout
+	if bf.SplitChr {
+		return bf.writeSplitByChr()
+	}
+	writer, err := bf.createOutput()
+	if err != nil {
+		return err
+	}
+	if err := bf.write(writer); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// Write one file per chromosome, each with the header of the bed file.
+// The chromosomes are written in the order they appear in
+func (bf *Bedfile) writeSplitByChr() error {
+	var chrs []string
+	chrLines := map[string][]Line{}
+	for _, l := range bf.Lines {
+		if _, ok := chrLines[l.Chr]; !ok {
+			chrs = append(chrs, l.Chr)
+		}
+		chrLines[l.Chr] = append(chrLines[l.Chr], l)
+	}
+	for _, chr := range chrs {
+		chrBed := *bf
+		chrBed.SplitChr = false
+		chrBed.Lines = chrLines[chr]
+		chrBed.Output = bf.splitChrPath(chr)
+		if err := chrBed.Write(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Path of the file for a chromosome when splitting by chromosome,
+// created from --split-chr-template and --output
+func (bf Bedfile) splitChrPath(chr string) string {
+	template := bf.SplitChrTemplate
+	if template == "" {
+		template = "{prefix}.{chr}{ext}"
+	}
+	prefix, ext := bf.Output, ".bed"
+	for _, e := range []string{".bed.gz", ".bed", ".gz"} {
+		if strings.HasSuffix(bf.Output, e) {
+			prefix, ext = strings.TrimSuffix(bf.Output, e), e
+			break
+		}
+	}
+	return strings.NewReplacer("{prefix}", prefix, "{chr}", chr, "{ext}", ext).Replace(template)
+}
+
+// Create the output destination, if output is not set write to stdout
+func (bf *Bedfile) createOutput() (io.WriteCloser, error) {
 	if bf.Output == "" {
-		return bf.write(os.Stdout)
+		return output{Writer: os.Stdout}, nil
 	}
 
 	// If output is set write to file
 	file, err := os.Create(bf.Output)
 	if err != nil {
-		return fmt.Errorf("cannot create output file: %v", err)
+		return nil, fmt.Errorf("cannot create output file: %v", err)
+	}
+	if !strings.HasSuffix(bf.Output, ".gz") {
+		return output{Writer: file, closers: []io.Closer{file}}, nil
+	}
+
+	// Compress output with BGZF if the output file ends with .gz
+	bgzf := newBgzfWriter(file)
+	if bf.Index != "" && bf.Index != NoneIT {
+		indexPath := fmt.Sprintf("%s.%s", bf.Output, bf.Index)
+		iw := newIndexWriter(bgzf, indexPath, bf.Index, bf.FirstBase)
+		return output{Writer: iw, closers: []io.Closer{iw, file}}, nil
 	}
-	defer file.Close()
-	return bf.write(file)
+	return output{Writer: bgzf, closers: []io.Closer{bgzf, file}}, nil
 }
 
 // Write bedfile content as string to writer destination
@@ -167,3 +167,52 @@ func TestToString(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitChrPath(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		testing      string
+		bed          Bedfile
+		chr          string
+		expectedPath string
+	}
+	testCases := []testCase{
+		{
+			testing:      "default template",
+			bed:          Bedfile{Output: "/some/panel.bed"},
+			chr:          "1",
+			expectedPath: "/some/panel.1.bed",
+		},
+		{
+			testing:      "default template with compressed output",
+			bed:          Bedfile{Output: "/some/panel.bed.gz"},
+			chr:          "X",
+			expectedPath: "/some/panel.X.bed.gz",
+		},
+		{
+			testing:      "default template with output without extension",
+			bed:          Bedfile{Output: "/some/panel"},
+			chr:          "chr2",
+			expectedPath: "/some/panel.chr2.bed",
+		},
+		{
+			testing: "custom template",
+			bed: Bedfile{
+				Output:           "/some/panel.bed",
+				SplitChrTemplate: "{prefix}_{chr}.txt",
+			},
+			chr:          "MT",
+			expectedPath: "/some/panel_MT.txt",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testing, func(t *testing.T) {
+			t.Parallel()
+			path := tc.bed.splitChrPath(tc.chr)
+			if path != tc.expectedPath {
+				t.Errorf("expected %q, got %q", tc.expectedPath, path)
+			}
+		})
+	}
+}